@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"time"
 
 	"manager/internal/config"
+	"manager/internal/notifier"
+	"manager/internal/repositories"
 	"manager/internal/routes"
+	"manager/internal/usecases"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -24,6 +29,39 @@ func main() {
 	}
 	defer db.Close()
 
+	// Tarefas em segundo plano
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	recurrenceUsecase := usecases.NewRecurrenceUsecase(repositories.NewRecurrenceRepository(db))
+	lookaheadDays := envInt("RECURRENCE_LOOKAHEAD_DAYS", 30)
+	startJob(ctx, "recurrences", envDuration("RECURRENCE_INTERVAL", time.Hour), func(ctx context.Context) error {
+		generated, err := recurrenceUsecase.GenerateDue(ctx, time.Now().AddDate(0, 0, lookaheadDays))
+		if generated > 0 {
+			log.Printf("[scheduler] recurrences: %d occurrences generated", generated)
+		}
+		return err
+	})
+
+	transactionUsecase := usecases.NewTransactionUsecase(repositories.NewUnitOfWork(db), repositories.NewTransactionRepository(db), repositories.NewRecurrenceRepository(db), repositories.NewPaymentRepository(db), repositories.NewAccountRepository(db), repositories.NewStatementRepository(db))
+	startJob(ctx, "overdue", envDuration("OVERDUE_INTERVAL", time.Hour), func(ctx context.Context) error {
+		result, err := transactionUsecase.MarkOverdue(ctx, time.Now())
+		if result != nil && result.Transactions+result.Installments > 0 {
+			log.Printf("[scheduler] overdue: %d transactions, %d installments marked", result.Transactions, result.Installments)
+		}
+		return err
+	})
+
+	budgetUsecase := usecases.NewBudgetUsecase(repositories.NewBudgetRepository(db), repositories.NewTransactionRepository(db), repositories.NewCategoryRepository(db))
+	alertUsecase := usecases.NewAlertUsecase(repositories.NewAlertRepository(db), repositories.NewNotificationRepository(db), budgetUsecase, repositories.NewTransactionRepository(db), repositories.NewAccountRepository(db), notifier.FromEnv())
+	startJob(ctx, "alerts", envDuration("ALERT_INTERVAL", time.Hour), func(ctx context.Context) error {
+		evaluation, err := alertUsecase.Evaluate(ctx, time.Now())
+		if evaluation != nil && len(evaluation.Notifications) > 0 {
+			log.Printf("[scheduler] alerts: %d notifications created", len(evaluation.Notifications))
+		}
+		return err
+	})
+
 	// Configurar Gin
 	if os.Getenv("GIN_MODE") == "" {
 		gin.SetMode(gin.ReleaseMode)
@@ -67,7 +105,16 @@ func main() {
 			"endpoints": []string{
 				"/api/transactions",
 				"/api/categories",
+				"/api/accounts",
+				"/api/recurrences",
+				"/api/budgets",
+				"/api/goals",
+				"/api/alerts",
+				"/api/notifications",
+				"/api/bills/upcoming",
+				"/api/bills/overdue",
 				"/api/dashboard/summary",
+				"/api/dashboard/timeseries",
 			},
 		})
 	})
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// startJob executa a tarefa imediatamente e depois a cada intervalo, até o contexto ser cancelado
+func startJob(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := job(ctx); err != nil {
+				log.Printf("[scheduler] %s failed: %v", name, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	log.Printf("[scheduler] %s scheduled every %s", name, interval)
+}
+
+// envDuration lê uma duração (ex: "1h", "15m") da variável de ambiente, com valor padrão
+func envDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s=%q, using %s", key, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
+
+// envInt lê um inteiro não negativo da variável de ambiente, com valor padrão
+func envInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Printf("Invalid %s=%q, using %d", key, value, fallback)
+		return fallback
+	}
+
+	return n
+}
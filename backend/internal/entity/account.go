@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+type AccountType string
+
+const (
+	AccountTypeChecking   AccountType = "checking"
+	AccountTypeSavings    AccountType = "savings"
+	AccountTypeCash       AccountType = "cash"
+	AccountTypeCreditCard AccountType = "credit_card"
+	AccountTypeInvestment AccountType = "investment"
+	AccountTypeOther      AccountType = "other"
+)
+
+type Account struct {
+	ID                  int64       `json:"id"`
+	Name                string      `json:"name"`
+	Type                AccountType `json:"type"`
+	OpeningBalanceCents int64       `json:"opening_balance_cents"`
+	Currency            string      `json:"currency"`
+	Archived            bool        `json:"archived"`
+	// Dia de fechamento e de vencimento da fatura (apenas cartões de crédito)
+	ClosingDay *int `json:"closing_day,omitempty"`
+	DueDay     *int `json:"due_day,omitempty"`
+	// Saldo inicial mais as movimentações realizadas na conta
+	BalanceCents int64     `json:"balance_cents"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,60 @@
+package entity
+
+import "time"
+
+type AlertType string
+
+const (
+	// Gasto da categoria atingiu um percentual do orçamento do mês
+	AlertTypeBudgetThreshold AlertType = "budget_threshold"
+	// Saldo de uma conta (ou o saldo total) abaixo de um valor
+	AlertTypeLowBalance AlertType = "low_balance"
+	// Conta a pagar vencendo nos próximos dias
+	AlertTypeBillDue AlertType = "bill_due"
+)
+
+// Canais de entrega de notificações. A lista no aplicativo (in_app) recebe todas as notificações.
+const (
+	NotificationChannelInApp   = "in_app"
+	NotificationChannelEmail   = "email"
+	NotificationChannelWebhook = "webhook"
+)
+
+// AlertRule é uma condição configurável que gera notificações quando atingida
+type AlertRule struct {
+	ID   int64     `json:"id"`
+	Name string    `json:"name"`
+	Type AlertType `json:"type"`
+	// budget_threshold
+	ThresholdPercent *int   `json:"threshold_percent,omitempty"`
+	CategoryID       *int64 `json:"category_id,omitempty"`
+	// low_balance
+	ThresholdCents *int64 `json:"threshold_cents,omitempty"`
+	AccountID      *int64 `json:"account_id,omitempty"`
+	// bill_due
+	DaysAhead *int     `json:"days_ahead,omitempty"`
+	Channels  []string `json:"channels"`
+	// Regras novas são criadas habilitadas quando o campo não é informado
+	Enabled   *bool     `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Notification é um alerta gerado por uma regra
+type Notification struct {
+	ID      int64     `json:"id"`
+	RuleID  *int64    `json:"rule_id,omitempty"`
+	Type    AlertType `json:"type"`
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+	// Identifica a condição que gerou a notificação, para não repeti-la
+	DedupeKey string     `json:"dedupe_key"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// AlertEvaluation resume uma avaliação das regras de alerta
+type AlertEvaluation struct {
+	Rules         int            `json:"rules"`
+	Notifications []Notification `json:"notifications"`
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// Bill é uma conta a pagar ou a receber: uma transação simples ou uma parcela de compra parcelada
+type Bill struct {
+	TransactionID     int64           `json:"transaction_id"`
+	InstallmentNumber *int            `json:"installment_number,omitempty"`
+	TotalInstallments *int            `json:"total_installments,omitempty"`
+	InstallmentLabel  string          `json:"installment_label,omitempty"`
+	Title             string          `json:"title"`
+	Type              TransactionType `json:"type"`
+	AmountCents       int64           `json:"amount_cents"`
+	DueDate           time.Time       `json:"due_date"`
+	Status            string          `json:"status"`
+	CategoryID        *int64          `json:"category_id,omitempty"`
+	AccountID         *int64          `json:"account_id,omitempty"`
+	Category          *Category       `json:"category,omitempty"`
+}
+
+// Status em aberto de uma conta: transações simples podem estar parcialmente pagas
+var BillOpenStatuses = []string{"pending", "partially_paid", "overdue"}
+
+// BillFilter seleciona contas em aberto por vencimento (inclusivo), status e tipo
+type BillFilter struct {
+	DueFrom  *time.Time
+	DueTo    *time.Time
+	Statuses []string
+	Type     TransactionType
+}
+
+// BillList é uma lista de contas em aberto com o total a pagar e a receber
+type BillList struct {
+	From            *time.Time `json:"from,omitempty"`
+	To              time.Time  `json:"to"`
+	PayableCents    int64      `json:"payable_cents"`
+	ReceivableCents int64      `json:"receivable_cents"`
+	Bills           []Bill     `json:"bills"`
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// Budget é o limite de gastos de uma categoria em um mês
+type Budget struct {
+	ID         int64     `json:"id"`
+	CategoryID int64     `json:"category_id"`
+	Month      time.Time `json:"month"`
+	LimitCents int64     `json:"limit_cents"`
+	// Se verdadeiro, o valor não gasto é somado ao orçamento do mês seguinte
+	Rollover  bool      `json:"rollover"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BudgetReportItem compara o orçado com o realizado de uma categoria no mês
+type BudgetReportItem struct {
+	BudgetID   int64     `json:"budget_id"`
+	CategoryID int64     `json:"category_id"`
+	Category   *Category `json:"category,omitempty"`
+	LimitCents int64     `json:"limit_cents"`
+	// Saldo não gasto trazido do mês anterior
+	RolloverCents  int64   `json:"rollover_cents"`
+	BudgetedCents  int64   `json:"budgeted_cents"`
+	ActualCents    int64   `json:"actual_cents"`
+	RemainingCents int64   `json:"remaining_cents"`
+	PercentUsed    float64 `json:"percent_used"`
+}
+
+type BudgetReport struct {
+	Month          time.Time          `json:"month"`
+	Items          []BudgetReportItem `json:"items"`
+	BudgetedCents  int64              `json:"budgeted_cents"`
+	ActualCents    int64              `json:"actual_cents"`
+	RemainingCents int64              `json:"remaining_cents"`
+	// Gastos do mês em categorias sem orçamento
+	UnbudgetedCents int64 `json:"unbudgeted_cents"`
+}
@@ -4,11 +4,14 @@ import "time"
 
 type Category struct {
 	ID          int64     `json:"id"`
+	ParentID    *int64    `json:"parent_id,omitempty"`
 	Name        string    `json:"name"`
 	Description *string   `json:"description,omitempty"`
 	Color       string    `json:"color"`
 	Icon        string    `json:"icon"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// Subcategorias, preenchidas apenas na listagem em árvore
+	Children []Category `json:"children,omitempty"`
 }
 
@@ -0,0 +1,34 @@
+package entity
+
+// Nome, cor e ícone do grupo de lançamentos sem categoria
+const (
+	UncategorizedName  = "Sem categoria"
+	UncategorizedColor = "#9CA3AF"
+	UncategorizedIcon  = "tag"
+)
+
+// CategoryBreakdownItem é o total de uma categoria no período, somado ao das subcategorias
+// (em Children). CategoryID nulo agrupa os lançamentos sem categoria.
+type CategoryBreakdownItem struct {
+	CategoryID       *int64  `json:"category_id"`
+	Name             string  `json:"name"`
+	Color            string  `json:"color"`
+	Icon             string  `json:"icon"`
+	TotalCents       int64   `json:"total_cents"`
+	Percent          float64 `json:"percent"`
+	TransactionCount int64   `json:"transaction_count"`
+
+	Children []CategoryBreakdownItem `json:"children,omitempty"`
+}
+
+type CategoryBreakdownSide struct {
+	TotalCents int64                   `json:"total_cents"`
+	Items      []CategoryBreakdownItem `json:"items"`
+}
+
+// CategoryBreakdown divide as despesas e as receitas do período por categoria
+type CategoryBreakdown struct {
+	Period  Period                `json:"period"`
+	Expense CategoryBreakdownSide `json:"expense"`
+	Income  CategoryBreakdownSide `json:"income"`
+}
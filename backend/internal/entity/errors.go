@@ -0,0 +1,12 @@
+package entity
+
+import "errors"
+
+var (
+	// ErrInvalidFilter indica parâmetros de listagem inválidos (filtro, ordenação ou cursor)
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrNotFound indica que o registro solicitado não existe
+	ErrNotFound = errors.New("not found")
+	// ErrConflict indica uma alteração incompatível com o estado atual (ex: parcelas já pagas)
+	ErrConflict = errors.New("conflict")
+)
@@ -0,0 +1,10 @@
+package entity
+
+// FieldSet guarda os campos JSON presentes no corpo de uma atualização, para diferenciar um campo
+// omitido de um campo enviado com valor zero ou nulo
+type FieldSet map[string]bool
+
+func (f FieldSet) Has(name string) bool {
+	return f[name]
+}
+
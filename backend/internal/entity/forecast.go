@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// Origem de um lançamento previsto no fluxo de caixa
+const (
+	ForecastSourceTransaction = "transaction"
+	ForecastSourceInstallment = "installment"
+	ForecastSourceRecurrence  = "recurrence"
+)
+
+// ForecastItem é um lançamento previsto em um dia do fluxo de caixa
+type ForecastItem struct {
+	Source            string          `json:"source"`
+	TransactionID     *int64          `json:"transaction_id,omitempty"`
+	InstallmentNumber *int            `json:"installment_number,omitempty"`
+	RecurrenceID      *int64          `json:"recurrence_id,omitempty"`
+	Title             string          `json:"title"`
+	Type              TransactionType `json:"type"`
+	AmountCents       int64           `json:"amount_cents"`
+	// Vencimento original, para lançamentos em atraso projetados no primeiro dia
+	DueDate time.Time `json:"due_date"`
+}
+
+// ForecastDay é o saldo projetado ao fim de um dia
+type ForecastDay struct {
+	Date         time.Time      `json:"date"`
+	IncomeCents  int64          `json:"income_cents"`
+	ExpenseCents int64          `json:"expense_cents"`
+	BalanceCents int64          `json:"balance_cents"`
+	Negative     bool           `json:"negative"`
+	Items        []ForecastItem `json:"items,omitempty"`
+}
+
+type CashFlowForecast struct {
+	From                 time.Time     `json:"from"`
+	To                   time.Time     `json:"to"`
+	StartingBalanceCents int64         `json:"starting_balance_cents"`
+	EndingBalanceCents   int64         `json:"ending_balance_cents"`
+	LowestBalanceCents   int64         `json:"lowest_balance_cents"`
+	LowestBalanceDate    time.Time     `json:"lowest_balance_date"`
+	FirstNegativeDate    *time.Time    `json:"first_negative_date,omitempty"`
+	NegativeDates        []time.Time   `json:"negative_dates"`
+	Days                 []ForecastDay `json:"days"`
+}
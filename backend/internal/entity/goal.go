@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+// Goal é uma meta de economia com valor e, opcionalmente, data alvo
+type Goal struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	TargetCents int64      `json:"target_cents"`
+	TargetDate  *time.Time `json:"target_date,omitempty"`
+	AccountID   *int64     `json:"account_id,omitempty"`
+	// Soma dos aportes (calculada)
+	SavedCents int64     `json:"saved_cents"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// GoalContribution é um aporte (ou resgate, se negativo) em uma meta
+type GoalContribution struct {
+	ID            int64     `json:"id"`
+	GoalID        int64     `json:"goal_id"`
+	TransactionID *int64    `json:"transaction_id,omitempty"`
+	AmountCents   int64     `json:"amount_cents"`
+	ContributedAt time.Time `json:"contributed_at"`
+	Notes         *string   `json:"notes,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// GoalProgress resume o andamento de uma meta e a projeção a partir do histórico de aportes
+type GoalProgress struct {
+	GoalID          int64      `json:"goal_id"`
+	TargetCents     int64      `json:"target_cents"`
+	SavedCents      int64      `json:"saved_cents"`
+	RemainingCents  int64      `json:"remaining_cents"`
+	PercentComplete float64    `json:"percent_complete"`
+	Completed       bool       `json:"completed"`
+	TargetDate      *time.Time `json:"target_date,omitempty"`
+	// Meses até a data alvo (incluindo o atual) e aporte mensal necessário para cumpri-la
+	MonthsRemaining      *int   `json:"months_remaining,omitempty"`
+	RequiredMonthlyCents *int64 `json:"required_monthly_cents,omitempty"`
+	// Média mensal de aportes desde o primeiro aporte
+	AverageMonthlyCents int64 `json:"average_monthly_cents"`
+	// Data prevista de conclusão mantida a média; ausente quando a média não é positiva
+	ProjectedCompletionDate *time.Time `json:"projected_completion_date,omitempty"`
+	// Se a projeção cumpre a data alvo (apenas para metas com data alvo)
+	OnTrack *bool `json:"on_track,omitempty"`
+}
@@ -11,6 +11,20 @@ const (
 	InstallmentStatusCancelled InstallmentStatus = "cancelled"
 )
 
+// InstallmentSchedule define como os vencimentos das parcelas são distribuídos
+type InstallmentSchedule string
+
+const (
+	// Mesmo dia a cada mês de calendário, limitado ao último dia do mês
+	InstallmentScheduleMonthly InstallmentSchedule = "monthly"
+	// A cada N dias (installment_interval_days)
+	InstallmentScheduleEveryNDays InstallmentSchedule = "every_n_days"
+	// A cada 7 dias
+	InstallmentScheduleWeekly InstallmentSchedule = "weekly"
+	// Lista de datas informada na criação (installment_dates)
+	InstallmentScheduleCustom InstallmentSchedule = "custom"
+)
+
 type Installment struct {
 	ID                int64             `json:"id"`
 	TransactionID     int64             `json:"transaction_id"`
@@ -19,7 +33,11 @@ type Installment struct {
 	DueDate           time.Time         `json:"due_date"`
 	Status            InstallmentStatus `json:"status"`
 	PaidAt            *time.Time        `json:"paid_at,omitempty"`
+	OverdueAt         *time.Time        `json:"overdue_at,omitempty"`
 	CreatedAt         time.Time         `json:"created_at"`
 	UpdatedAt         time.Time         `json:"updated_at"`
+	// Total pago e saldo em aberto, calculados a partir dos pagamentos
+	PaidCents        *int64 `json:"paid_cents,omitempty"`
+	OutstandingCents *int64 `json:"outstanding_cents,omitempty"`
 }
 
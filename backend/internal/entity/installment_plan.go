@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// AmortizationMethod define como os juros são aplicados às parcelas
+type AmortizationMethod string
+
+const (
+	// Tabela Price: parcelas iguais, juros decrescentes
+	AmortizationMethodPrice AmortizationMethod = "price"
+	// SAC: amortização constante, parcelas decrescentes
+	AmortizationMethodSAC AmortizationMethod = "sac"
+)
+
+// RemainderPolicy define em quais parcelas ficam os centavos do arredondamento
+type RemainderPolicy string
+
+const (
+	RemainderPolicyFirst  RemainderPolicy = "first"
+	RemainderPolicyLast   RemainderPolicy = "last"
+	RemainderPolicySpread RemainderPolicy = "spread"
+)
+
+// InstallmentPlanEntry é uma linha do cronograma; a entrada, quando existe, é a parcela 0
+type InstallmentPlanEntry struct {
+	InstallmentNumber int       `json:"installment_number"`
+	DueDate           time.Time `json:"due_date"`
+	AmountCents       int64     `json:"amount_cents"`
+	PrincipalCents    int64     `json:"principal_cents"`
+	InterestCents     int64     `json:"interest_cents"`
+	BalanceCents      int64     `json:"balance_cents"`
+}
+
+type InstallmentPlan struct {
+	DownPaymentCents   int64                  `json:"down_payment_cents"`
+	FinancedCents      int64                  `json:"financed_cents"`
+	TotalInterestCents int64                  `json:"total_interest_cents"`
+	TotalCents         int64                  `json:"total_cents"`
+	Entries            []InstallmentPlanEntry `json:"entries"`
+}
+
+// InstallmentChanges descreve como o cronograma foi reconciliado na atualização da transação
+type InstallmentChanges struct {
+	Created   []int    `json:"created"`
+	Updated   []int    `json:"updated"`
+	Removed   []int    `json:"removed"`
+	Preserved []int    `json:"preserved"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// Payment é um pagamento (total ou parcial) de uma transação simples ou de uma parcela
+type Payment struct {
+	ID                int64     `json:"id"`
+	TransactionID     int64     `json:"transaction_id"`
+	InstallmentID     *int64    `json:"installment_id,omitempty"`
+	InstallmentNumber *int      `json:"installment_number,omitempty"`
+	AmountCents       int64     `json:"amount_cents"`
+	PaidAt            time.Time `json:"paid_at"`
+	Notes             *string   `json:"notes,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Period é um intervalo de datas com início e fim inclusivos
+type Period struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// MonthPeriod retorna o mês que contém a data
+func MonthPeriod(date time.Time) Period {
+	from := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return Period{From: from, To: from.AddDate(0, 1, -1)}
+}
+
+// YearPeriod retorna o ano inteiro
+func YearPeriod(year int) Period {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return Period{From: from, To: from.AddDate(1, 0, -1)}
+}
+
+func NewPeriod(from, to time.Time) (Period, error) {
+	period := Period{
+		From: time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC),
+		To:   time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC),
+	}
+
+	if period.To.Before(period.From) {
+		return Period{}, fmt.Errorf("%w: period end must not be before its start", ErrInvalidFilter)
+	}
+
+	return period, nil
+}
+
+// End retorna o dia seguinte ao fim do período, para consultas com intervalo semiaberto
+func (p Period) End() time.Time {
+	return p.To.AddDate(0, 0, 1)
+}
+
+// wholeMonths retorna quantos meses completos o período cobre, ou 0 se não começa no primeiro
+// dia de um mês e termina no último dia de outro
+func (p Period) wholeMonths() int {
+	if p.From.Day() != 1 || p.End().Day() != 1 {
+		return 0
+	}
+	return (p.End().Year()-p.From.Year())*12 + int(p.End().Month()) - int(p.From.Month())
+}
+
+// Previous retorna o período de mesma duração imediatamente anterior. Períodos de meses
+// completos são comparados com a mesma quantidade de meses (ex: março com fevereiro).
+func (p Period) Previous() Period {
+	if months := p.wholeMonths(); months > 0 {
+		from := p.From.AddDate(0, -months, 0)
+		return Period{From: from, To: p.From.AddDate(0, 0, -1)}
+	}
+
+	days := int(p.End().Sub(p.From).Hours() / 24)
+	return Period{From: p.From.AddDate(0, 0, -days), To: p.From.AddDate(0, 0, -1)}
+}
+
+// PreviousYear retorna o mesmo período um ano antes
+func (p Period) PreviousYear() Period {
+	if months := p.wholeMonths(); months > 0 {
+		from := p.From.AddDate(-1, 0, 0)
+		return Period{From: from, To: from.AddDate(0, months, -1)}
+	}
+
+	return Period{From: p.From.AddDate(-1, 0, 0), To: p.To.AddDate(-1, 0, 0)}
+}
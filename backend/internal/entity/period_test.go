@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPeriodPrevious(t *testing.T) {
+	tests := []struct {
+		name         string
+		period       Period
+		wantPrevious Period
+		wantYear     Period
+	}{
+		{
+			name:         "march",
+			period:       MonthPeriod(day(2025, 3, 15)),
+			wantPrevious: Period{From: day(2025, 2, 1), To: day(2025, 2, 28)},
+			wantYear:     Period{From: day(2024, 3, 1), To: day(2024, 3, 31)},
+		},
+		{
+			name:         "february in a leap year",
+			period:       MonthPeriod(day(2024, 2, 10)),
+			wantPrevious: Period{From: day(2024, 1, 1), To: day(2024, 1, 31)},
+			wantYear:     Period{From: day(2023, 2, 1), To: day(2023, 2, 28)},
+		},
+		{
+			name:         "january crosses the year",
+			period:       MonthPeriod(day(2025, 1, 1)),
+			wantPrevious: Period{From: day(2024, 12, 1), To: day(2024, 12, 31)},
+			wantYear:     Period{From: day(2024, 1, 1), To: day(2024, 1, 31)},
+		},
+		{
+			name:         "quarter",
+			period:       Period{From: day(2025, 4, 1), To: day(2025, 6, 30)},
+			wantPrevious: Period{From: day(2025, 1, 1), To: day(2025, 3, 31)},
+			wantYear:     Period{From: day(2024, 4, 1), To: day(2024, 6, 30)},
+		},
+		{
+			name:         "year",
+			period:       YearPeriod(2025),
+			wantPrevious: Period{From: day(2024, 1, 1), To: day(2024, 12, 31)},
+			wantYear:     Period{From: day(2024, 1, 1), To: day(2024, 12, 31)},
+		},
+		{
+			name:         "date range uses the same number of days",
+			period:       Period{From: day(2025, 3, 10), To: day(2025, 3, 19)},
+			wantPrevious: Period{From: day(2025, 2, 28), To: day(2025, 3, 9)},
+			wantYear:     Period{From: day(2024, 3, 10), To: day(2024, 3, 19)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.period.Previous(); got != tt.wantPrevious {
+				t.Errorf("Previous() = %v, want %v", got, tt.wantPrevious)
+			}
+			if got := tt.period.PreviousYear(); got != tt.wantYear {
+				t.Errorf("PreviousYear() = %v, want %v", got, tt.wantYear)
+			}
+		})
+	}
+}
+
+func TestNewPeriod(t *testing.T) {
+	if _, err := NewPeriod(day(2025, 3, 2), day(2025, 3, 1)); err == nil {
+		t.Error("NewPeriod with end before start: error = nil")
+	}
+
+	period, err := NewPeriod(time.Date(2025, 3, 1, 15, 30, 0, 0, time.UTC), day(2025, 3, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if period.From != day(2025, 3, 1) || period.End() != day(2025, 3, 2) {
+		t.Errorf("period = %v, end = %v", period, period.End())
+	}
+}
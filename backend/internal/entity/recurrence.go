@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceFrequencyDaily   RecurrenceFrequency = "daily"
+	RecurrenceFrequencyWeekly  RecurrenceFrequency = "weekly"
+	RecurrenceFrequencyMonthly RecurrenceFrequency = "monthly"
+	RecurrenceFrequencyYearly  RecurrenceFrequency = "yearly"
+)
+
+type RecurrenceStatus string
+
+const (
+	RecurrenceStatusActive   RecurrenceStatus = "active"
+	RecurrenceStatusPaused   RecurrenceStatus = "paused"
+	RecurrenceStatusFinished RecurrenceStatus = "finished"
+)
+
+type Recurrence struct {
+	ID               int64               `json:"id"`
+	Title            string              `json:"title"`
+	Description      *string             `json:"description,omitempty"`
+	AmountCents      int64               `json:"amount_cents"`
+	Type             TransactionType     `json:"type"`
+	CategoryID       *int64              `json:"category_id,omitempty"`
+	Frequency        RecurrenceFrequency `json:"frequency"`
+	Interval         int                 `json:"interval"`
+	DayOfMonth       *int                `json:"day_of_month,omitempty"`
+	StartDate        time.Time           `json:"start_date"`
+	EndDate          *time.Time          `json:"end_date,omitempty"`
+	MaxOccurrences   *int                `json:"max_occurrences,omitempty"`
+	OccurrencesCount int                 `json:"occurrences_count"`
+	NextDueDate      time.Time           `json:"next_due_date"`
+	Status           RecurrenceStatus    `json:"status"`
+	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at"`
+}
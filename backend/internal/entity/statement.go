@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+type StatementStatus string
+
+const (
+	// Fatura ainda recebendo compras
+	StatementStatusOpen StatementStatus = "open"
+	// Fatura fechada, aguardando pagamento
+	StatementStatusClosed StatementStatus = "closed"
+	StatementStatusPaid   StatementStatus = "paid"
+)
+
+// Statement é a fatura de um cartão de crédito
+type Statement struct {
+	ID                   int64           `json:"id"`
+	AccountID            int64           `json:"account_id"`
+	ClosingDate          time.Time       `json:"closing_date"`
+	DueDate              time.Time       `json:"due_date"`
+	Status               StatementStatus `json:"status"`
+	PaidAt               *time.Time      `json:"paid_at,omitempty"`
+	PaymentTransactionID *int64          `json:"payment_transaction_id,omitempty"`
+	// Total das compras menos estornos, e quanto desse total já foi quitado
+	TotalCents int64           `json:"total_cents"`
+	PaidCents  int64           `json:"paid_cents"`
+	ItemCount  int             `json:"item_count"`
+	Items      []StatementItem `json:"items,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// StatementItem é uma compra simples ou uma parcela lançada na fatura
+type StatementItem struct {
+	TransactionID     int64           `json:"transaction_id"`
+	InstallmentNumber *int            `json:"installment_number,omitempty"`
+	TotalInstallments *int            `json:"total_installments,omitempty"`
+	Title             string          `json:"title"`
+	Type              TransactionType `json:"type"`
+	AmountCents       int64           `json:"amount_cents"`
+	Date              time.Time       `json:"date"`
+	Status            string          `json:"status"`
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// Intervalos da série temporal
+const (
+	TimeSeriesDay   = "day"
+	TimeSeriesWeek  = "week"
+	TimeSeriesMonth = "month"
+)
+
+// Agrupamentos opcionais da série temporal
+const (
+	TimeSeriesGroupByCategory = "category"
+	TimeSeriesGroupByAccount  = "account"
+)
+
+// TimeSeriesPoint traz os totais de um intervalo, identificado pelo seu primeiro dia
+type TimeSeriesPoint struct {
+	Start   time.Time `json:"start"`
+	Income  int64     `json:"income"`
+	Expense int64     `json:"expense"`
+	Net     int64     `json:"net"`
+	// Na série total, o saldo quitado ao fim do intervalo, pela data dos pagamentos (como o saldo
+	// total do resumo); nos grupos, o resultado acumulado desde o início do período
+	CumulativeCents int64 `json:"cumulative_cents"`
+}
+
+// TimeSeriesGroup é a série de uma categoria ou conta. ID nulo agrupa os lançamentos sem categoria/conta.
+type TimeSeriesGroup struct {
+	ID     *int64            `json:"id"`
+	Name   string            `json:"name"`
+	Points []TimeSeriesPoint `json:"points"`
+}
+
+type TimeSeries struct {
+	Period      Period            `json:"period"`
+	Granularity string            `json:"granularity"`
+	GroupBy     string            `json:"group_by,omitempty"`
+	Points      []TimeSeriesPoint `json:"points"`
+	Groups      []TimeSeriesGroup `json:"groups,omitempty"`
+}
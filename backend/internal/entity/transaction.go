@@ -7,32 +7,54 @@ type TransactionType string
 const (
 	TransactionTypeIncome  TransactionType = "income"
 	TransactionTypeExpense TransactionType = "expense"
+	// Transferência entre contas: não é receita nem despesa
+	TransactionTypeTransfer TransactionType = "transfer"
 )
 
 type TransactionStatus string
 
 const (
-	TransactionStatusPending   TransactionStatus = "pending"
-	TransactionStatusPaid      TransactionStatus = "paid"
-	TransactionStatusOverdue   TransactionStatus = "overdue"
-	TransactionStatusCancelled TransactionStatus = "cancelled"
+	TransactionStatusPending       TransactionStatus = "pending"
+	TransactionStatusPaid          TransactionStatus = "paid"
+	TransactionStatusPartiallyPaid TransactionStatus = "partially_paid"
+	TransactionStatusOverdue       TransactionStatus = "overdue"
+	TransactionStatusCancelled     TransactionStatus = "cancelled"
 )
 
 type Transaction struct {
-	ID                int64             `json:"id"`
-	Title             string            `json:"title"`
-	Description       *string           `json:"description,omitempty"`
-	AmountCents       int64             `json:"amount_cents"`
-	Type              TransactionType   `json:"type"`
-	CategoryID        *int64            `json:"category_id,omitempty"`
-	DueDate           time.Time         `json:"due_date"`
-	IsRecurring       bool              `json:"is_recurring"`
-	IsInstallment     bool              `json:"is_installment"`
-	TotalInstallments int               `json:"total_installments"`
-	Status            TransactionStatus `json:"status"`
-	CreatedAt         time.Time         `json:"created_at"`
-	UpdatedAt         time.Time         `json:"updated_at"`
-	Category          *Category         `json:"category,omitempty"`
-	Installments      []Installment     `json:"installments,omitempty"`
+	ID                      int64               `json:"id"`
+	Title                   string              `json:"title"`
+	Description             *string             `json:"description,omitempty"`
+	AmountCents             int64               `json:"amount_cents"`
+	Type                    TransactionType     `json:"type"`
+	CategoryID              *int64              `json:"category_id,omitempty"`
+	AccountID               *int64              `json:"account_id,omitempty"`
+	DestinationAccountID    *int64              `json:"destination_account_id,omitempty"`
+	DueDate                 time.Time           `json:"due_date"`
+	IsRecurring             bool                `json:"is_recurring"`
+	RecurrenceID            *int64              `json:"recurrence_id,omitempty"`
+	IsInstallment           bool                `json:"is_installment"`
+	TotalInstallments       int                 `json:"total_installments"`
+	InstallmentSchedule     InstallmentSchedule `json:"installment_schedule,omitempty"`
+	InstallmentIntervalDays *int                `json:"installment_interval_days,omitempty"`
+	InstallmentDates        []time.Time         `json:"installment_dates,omitempty"`
+	InterestRate            float64             `json:"interest_rate,omitempty"`
+	AmortizationMethod      AmortizationMethod  `json:"amortization_method,omitempty"`
+	DownPaymentCents        int64               `json:"down_payment_cents,omitempty"`
+	RemainderPolicy         RemainderPolicy     `json:"remainder_policy,omitempty"`
+	Status                  TransactionStatus   `json:"status"`
+	OverdueAt               *time.Time          `json:"overdue_at,omitempty"`
+	PaidAt                  *time.Time          `json:"paid_at,omitempty"`
+	PaidAmountCents         *int64              `json:"paid_amount_cents,omitempty"`
+	SettledAmountCents      *int64              `json:"settled_amount_cents,omitempty"`
+	CreatedAt               time.Time           `json:"created_at"`
+	UpdatedAt               time.Time           `json:"updated_at"`
+	Category                *Category           `json:"category,omitempty"`
+	Installments            []Installment       `json:"installments,omitempty"`
+	Recurrence              *Recurrence         `json:"recurrence,omitempty"`
+	InstallmentChanges      *InstallmentChanges `json:"installment_changes,omitempty"`
+	Payments                []Payment           `json:"payments,omitempty"`
+	// Total pago e saldo em aberto, calculados a partir dos pagamentos
+	PaidCents        *int64 `json:"paid_cents,omitempty"`
+	OutstandingCents *int64 `json:"outstanding_cents,omitempty"`
 }
-
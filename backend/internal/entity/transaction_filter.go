@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// Campos aceitos para ordenação da listagem de transações
+const (
+	TransactionSortDueDate   = "due_date"
+	TransactionSortAmount    = "amount_cents"
+	TransactionSortCreatedAt = "created_at"
+	TransactionSortTitle     = "title"
+)
+
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+type TransactionFilter struct {
+	DueFrom        *time.Time
+	DueTo          *time.Time
+	Types          []TransactionType
+	Statuses       []TransactionStatus
+	CategoryIDs    []int64
+	AccountIDs     []int64
+	MinAmountCents *int64
+	MaxAmountCents *int64
+	Search         string
+	SortBy         string
+	SortDirection  string
+	Limit          int
+	Cursor         string
+}
+
+type TransactionPage struct {
+	Data       []Transaction `json:"data"`
+	Total      int64         `json:"total"`
+	NextCursor *string       `json:"next_cursor"`
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"manager/internal/entity"
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AlertHandler struct {
+	usecase *usecases.AlertUsecase
+}
+
+func NewAlertHandler(usecase *usecases.AlertUsecase) *AlertHandler {
+	return &AlertHandler{usecase: usecase}
+}
+
+func (h *AlertHandler) Create(c *gin.Context) {
+	var rule entity.AlertRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.usecase.Create(c.Request.Context(), &rule); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *AlertHandler) GetAll(c *gin.Context) {
+	rules, err := h.usecase.GetAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+func (h *AlertHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	rule, err := h.usecase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *AlertHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var rule entity.AlertRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule.ID = id
+
+	if err := h.usecase.Update(c.Request.Context(), &rule); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *AlertHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.usecase.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "alert rule deleted"})
+}
+
+// Evaluate avalia as regras sob demanda e retorna as notificações geradas
+func (h *AlertHandler) Evaluate(c *gin.Context) {
+	evaluation, err := h.usecase.Evaluate(c.Request.Context(), time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, evaluation)
+}
+
+// GetNotifications lista as notificações. Parâmetro: unread (true/false).
+func (h *AlertHandler) GetNotifications(c *gin.Context) {
+	onlyUnread, _ := strconv.ParseBool(c.Query("unread"))
+
+	notifications, err := h.usecase.GetNotifications(c.Request.Context(), onlyUnread)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+func (h *AlertHandler) MarkNotificationRead(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.usecase.MarkNotificationRead(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "notification marked as read"})
+}
+
+func (h *AlertHandler) MarkAllNotificationsRead(c *gin.Context) {
+	count, err := h.usecase.MarkAllNotificationsRead(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": count})
+}
+
+// EvaluateAlertsAfterWrite reavalia as regras de alerta após cada escrita bem-sucedida do grupo
+// de rotas. A avaliação roda em segundo plano para não atrasar a resposta.
+func EvaluateAlertsAfterWrite(usecase *usecases.AlertUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Request.Method == http.MethodGet || c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			if _, err := usecase.Evaluate(ctx, time.Now()); err != nil {
+				log.Printf("[alerts] evaluation failed: %v", err)
+			}
+		}()
+	}
+}
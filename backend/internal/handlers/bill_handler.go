@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"manager/internal/entity"
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BillHandler struct {
+	usecase *usecases.BillUsecase
+}
+
+func NewBillHandler(usecase *usecases.BillUsecase) *BillHandler {
+	return &BillHandler{usecase: usecase}
+}
+
+// GetUpcoming lista as contas pendentes que vencem nos próximos dias. Parâmetros: days (padrão 7)
+// e type (income ou expense; padrão: ambos).
+func (h *BillHandler) GetUpcoming(c *gin.Context) {
+	days := 7
+	if value := c.Query("days"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days"})
+			return
+		}
+		days = n
+	}
+
+	bills, err := h.usecase.GetUpcoming(c.Request.Context(), time.Now(), days, entity.TransactionType(c.Query("type")))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bills)
+}
+
+// GetOverdue lista as contas em aberto com vencimento passado. Parâmetro: type (income ou expense).
+func (h *BillHandler) GetOverdue(c *gin.Context) {
+	bills, err := h.usecase.GetOverdue(c.Request.Context(), time.Now(), entity.TransactionType(c.Query("type")))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bills)
+}
+
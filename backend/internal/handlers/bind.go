@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+
+	"manager/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindJSONFields decodifica o corpo em v e retorna os campos presentes no objeto JSON
+func bindJSONFields(c *gin.Context, v any) (entity.FieldSet, error) {
+	body, err := c.GetRawData()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	fields := make(entity.FieldSet, len(raw))
+	for name := range raw {
+		fields[name] = true
+	}
+
+	return fields, nil
+}
+
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"manager/internal/entity"
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BudgetHandler struct {
+	usecase *usecases.BudgetUsecase
+}
+
+func NewBudgetHandler(usecase *usecases.BudgetUsecase) *BudgetHandler {
+	return &BudgetHandler{usecase: usecase}
+}
+
+func (h *BudgetHandler) Create(c *gin.Context) {
+	var budget entity.Budget
+	if err := c.ShouldBindJSON(&budget); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.usecase.Create(c.Request.Context(), &budget); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, budget)
+}
+
+// GetAll lista os orçamentos do mês. Parâmetro: month (YYYY-MM, padrão: mês atual).
+func (h *BudgetHandler) GetAll(c *gin.Context) {
+	month, err := parseMonthQuery(c, "month")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	budgets, err := h.usecase.GetAll(c.Request.Context(), month)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, budgets)
+}
+
+// Report compara orçado, realizado e restante por categoria. Parâmetro: month (YYYY-MM).
+func (h *BudgetHandler) Report(c *gin.Context) {
+	month, err := parseMonthQuery(c, "month")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.usecase.Report(c.Request.Context(), month)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *BudgetHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	budget, err := h.usecase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+func (h *BudgetHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var budget entity.Budget
+	if err := c.ShouldBindJSON(&budget); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	budget.ID = id
+
+	if err := h.usecase.Update(c.Request.Context(), &budget); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+func (h *BudgetHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.usecase.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "budget deleted"})
+}
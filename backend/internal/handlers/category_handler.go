@@ -26,7 +26,7 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.usecase.Create(c.Request.Context(), &category); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -75,7 +75,7 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 	category.ID = id
 
 	if err := h.usecase.Update(c.Request.Context(), &category); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"manager/internal/entity"
 	"manager/internal/usecases"
 
 	"github.com/gin-gonic/gin"
@@ -16,8 +20,22 @@ func NewDashboardHandler(usecase *usecases.DashboardUsecase) *DashboardHandler {
 	return &DashboardHandler{usecase: usecase}
 }
 
+// GetSummary retorna o resumo do período. Parâmetros: month (YYYY-MM), year (YYYY) ou from/to
+// (YYYY-MM-DD); compare (previous ou previous_year) ou compare_from/compare_to.
 func (h *DashboardHandler) GetSummary(c *gin.Context) {
-	summary, err := h.usecase.GetSummary(c.Request.Context())
+	period, err := parsePeriodQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comparison, err := parseComparisonQuery(c, period)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary, err := h.usecase.GetSummary(c.Request.Context(), period, comparison)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -26,3 +44,133 @@ func (h *DashboardHandler) GetSummary(c *gin.Context) {
 	c.JSON(http.StatusOK, summary)
 }
 
+// parseComparisonQuery lê o período de comparação: compare=previous (período anterior de mesma
+// duração), compare=previous_year (mesmo período no ano anterior) ou compare_from/compare_to
+func parseComparisonQuery(c *gin.Context, period entity.Period) (*entity.Period, error) {
+	from, err := parseDateQuery(c, "compare_from")
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := parseDateQuery(c, "compare_to")
+	if err != nil {
+		return nil, err
+	}
+
+	if from != nil || to != nil {
+		if from == nil || to == nil {
+			return nil, fmt.Errorf("compare_from and compare_to must be informed together")
+		}
+		if c.Query("compare") != "" {
+			return nil, fmt.Errorf("use either compare or compare_from/compare_to")
+		}
+
+		comparison, err := entity.NewPeriod(*from, *to)
+		if err != nil {
+			return nil, err
+		}
+		return &comparison, nil
+	}
+
+	switch c.Query("compare") {
+	case "":
+		return nil, nil
+	case "previous":
+		comparison := period.Previous()
+		return &comparison, nil
+	case "previous_year":
+		comparison := period.PreviousYear()
+		return &comparison, nil
+	default:
+		return nil, fmt.Errorf("invalid compare, expected previous or previous_year")
+	}
+}
+
+// GetTimeSeries retorna a série temporal de receitas, despesas, resultado e saldo acumulado.
+// Parâmetros: months (últimos N meses, padrão 12) ou month/year/from/to; granularity (day, week,
+// month; padrão month); group_by (category ou account, opcional).
+func (h *DashboardHandler) GetTimeSeries(c *gin.Context) {
+	period, err := parseTimeSeriesPeriod(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series, err := h.usecase.GetTimeSeries(c.Request.Context(), period, c.Query("granularity"), c.Query("group_by"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+func parseTimeSeriesPeriod(c *gin.Context) (entity.Period, error) {
+	value := c.Query("months")
+	if value == "" && (c.Query("month") != "" || c.Query("year") != "" || c.Query("from") != "" || c.Query("to") != "") {
+		return parsePeriodQuery(c)
+	}
+
+	months := 12
+	if value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 120 {
+			return entity.Period{}, fmt.Errorf("invalid months, expected 1 to 120")
+		}
+		months = n
+	}
+
+	current := entity.MonthPeriod(time.Now())
+	return entity.Period{From: current.From.AddDate(0, 1-months, 0), To: current.To}, nil
+}
+
+// GetForecast projeta o saldo diário. Parâmetros: days (padrão 30, máximo 365) e
+// include_overdue (padrão true).
+func (h *DashboardHandler) GetForecast(c *gin.Context) {
+	days := 30
+	if value := c.Query("days"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days"})
+			return
+		}
+		days = n
+	}
+
+	includeOverdue := true
+	if value := c.Query("include_overdue"); value != "" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_overdue"})
+			return
+		}
+		includeOverdue = b
+	}
+
+	forecast, err := h.usecase.GetForecast(c.Request.Context(), time.Now(), days, includeOverdue)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, forecast)
+}
+
+// GetCategoryBreakdown divide despesas e receitas do período por categoria. Parâmetros: month
+// (YYYY-MM), year (YYYY) ou from/to (YYYY-MM-DD); padrão: mês atual.
+func (h *DashboardHandler) GetCategoryBreakdown(c *gin.Context) {
+	period, err := parsePeriodQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	breakdown, err := h.usecase.GetCategoryBreakdown(c.Request.Context(), period)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, breakdown)
+}
+
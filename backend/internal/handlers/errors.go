@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"manager/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondError traduz os erros de domínio para o status HTTP correspondente
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, entity.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, entity.ErrInvalidFilter):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
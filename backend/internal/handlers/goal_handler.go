@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"manager/internal/entity"
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GoalHandler struct {
+	usecase *usecases.GoalUsecase
+}
+
+func NewGoalHandler(usecase *usecases.GoalUsecase) *GoalHandler {
+	return &GoalHandler{usecase: usecase}
+}
+
+func (h *GoalHandler) Create(c *gin.Context) {
+	var goal entity.Goal
+	if err := c.ShouldBindJSON(&goal); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.usecase.Create(c.Request.Context(), &goal); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, goal)
+}
+
+func (h *GoalHandler) GetAll(c *gin.Context) {
+	goals, err := h.usecase.GetAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, goals)
+}
+
+func (h *GoalHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	goal, err := h.usecase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
+
+func (h *GoalHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var goal entity.Goal
+	if err := c.ShouldBindJSON(&goal); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	goal.ID = id
+
+	if err := h.usecase.Update(c.Request.Context(), &goal); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
+
+func (h *GoalHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.usecase.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "goal deleted"})
+}
+
+// GetProgress retorna o andamento da meta, o aporte mensal necessário e a data prevista de conclusão
+func (h *GoalHandler) GetProgress(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	progress, err := h.usecase.GetProgress(c.Request.Context(), id, time.Now())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+func (h *GoalHandler) ListContributions(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	contributions, err := h.usecase.ListContributions(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contributions)
+}
+
+func (h *GoalHandler) AddContribution(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var contribution entity.GoalContribution
+	if err := c.ShouldBindJSON(&contribution); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.usecase.AddContribution(c.Request.Context(), id, &contribution); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contribution)
+}
+
+func (h *GoalHandler) DeleteContribution(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	contributionID, err := strconv.ParseInt(c.Param("contribution"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid contribution id"})
+		return
+	}
+
+	if err := h.usecase.DeleteContribution(c.Request.Context(), id, contributionID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "contribution deleted"})
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"manager/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// queryList aceita parâmetros repetidos (?a=1&a=2) e separados por vírgula (?a=1,2)
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+func parseDateQuery(c *gin.Context, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s date, expected YYYY-MM-DD", key)
+	}
+
+	return &date, nil
+}
+
+func parseInt64Query(c *gin.Context, key string) (*int64, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+
+	return &n, nil
+}
+
+// parseMonthQuery lê um mês no formato YYYY-MM; sem o parâmetro, retorna o mês atual
+func parseMonthQuery(c *gin.Context, key string) (time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	month, err := time.Parse("2006-01", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s, expected YYYY-MM", key)
+	}
+
+	return month, nil
+}
+
+// parsePeriodQuery lê o período a partir de month (YYYY-MM), year (YYYY) ou from/to (YYYY-MM-DD,
+// inclusivos). Sem parâmetros, retorna o mês atual.
+func parsePeriodQuery(c *gin.Context) (entity.Period, error) {
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		return entity.Period{}, err
+	}
+
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		return entity.Period{}, err
+	}
+
+	month, year := c.Query("month"), c.Query("year")
+
+	given := 0
+	for _, present := range []bool{month != "", year != "", from != nil || to != nil} {
+		if present {
+			given++
+		}
+	}
+	if given > 1 {
+		return entity.Period{}, fmt.Errorf("use only one of month, year or from/to")
+	}
+
+	switch {
+	case from != nil || to != nil:
+		if from == nil || to == nil {
+			return entity.Period{}, fmt.Errorf("from and to must be informed together")
+		}
+		return entity.NewPeriod(*from, *to)
+	case year != "":
+		y, err := strconv.Atoi(year)
+		if err != nil || y < 1 || y > 9999 {
+			return entity.Period{}, fmt.Errorf("invalid year, expected YYYY")
+		}
+		return entity.YearPeriod(y), nil
+	default:
+		m, err := parseMonthQuery(c, "month")
+		if err != nil {
+			return entity.Period{}, err
+		}
+		return entity.MonthPeriod(m), nil
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatementHandler struct {
+	usecase *usecases.TransactionUsecase
+}
+
+func NewStatementHandler(usecase *usecases.TransactionUsecase) *StatementHandler {
+	return &StatementHandler{usecase: usecase}
+}
+
+func (h *StatementHandler) GetAll(c *gin.Context) {
+	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	statements, err := h.usecase.ListStatements(c.Request.Context(), accountID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statements)
+}
+
+func (h *StatementHandler) GetByID(c *gin.Context) {
+	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	statementID, err := strconv.ParseInt(c.Param("statement"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid statement id"})
+		return
+	}
+
+	statement, err := h.usecase.GetStatement(c.Request.Context(), accountID, statementID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statement)
+}
+
+type payStatementRequest struct {
+	FromAccountID *int64     `json:"from_account_id"`
+	PaidAt        *time.Time `json:"paid_at"`
+}
+
+func (h *StatementHandler) Pay(c *gin.Context) {
+	accountID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	statementID, err := strconv.ParseInt(c.Param("statement"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid statement id"})
+		return
+	}
+
+	// O corpo é opcional: sem ele, quita os lançamentos sem registrar transferência
+	var req payStatementRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	statement, err := h.usecase.PayStatement(c.Request.Context(), accountID, statementID, req.FromAccountID, req.PaidAt)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statement)
+}
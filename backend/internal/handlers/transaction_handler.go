@@ -3,6 +3,8 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"manager/internal/entity"
 	"manager/internal/usecases"
@@ -26,28 +28,92 @@ func (h *TransactionHandler) Create(c *gin.Context) {
 	}
 
 	if err := h.usecase.Create(c.Request.Context(), &transaction); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	// Buscar transação completa com parcelas
 	createdTransaction, err := h.usecase.GetByID(c.Request.Context(), transaction.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, createdTransaction)
 }
 
+// GetAll lista as transações com filtros, ordenação e paginação por cursor.
+// Parâmetros: from, to (YYYY-MM-DD), type, status, category_id, account_id (repetidos ou separados por vírgula),
+// min_amount, max_amount (centavos), q, sort, order (asc/desc), limit, cursor.
 func (h *TransactionHandler) GetAll(c *gin.Context) {
-	transactions, err := h.usecase.GetAll(c.Request.Context())
+	filter := entity.TransactionFilter{
+		Search:        strings.TrimSpace(c.Query("q")),
+		SortBy:        c.Query("sort"),
+		SortDirection: strings.ToLower(c.Query("order")),
+		Cursor:        c.Query("cursor"),
+	}
+
+	var err error
+	if filter.DueFrom, err = parseDateQuery(c, "from"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if filter.DueTo, err = parseDateQuery(c, "to"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, value := range queryList(c, "type") {
+		filter.Types = append(filter.Types, entity.TransactionType(value))
+	}
+
+	for _, value := range queryList(c, "status") {
+		filter.Statuses = append(filter.Statuses, entity.TransactionStatus(value))
+	}
+
+	for _, value := range queryList(c, "category_id") {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category_id"})
+			return
+		}
+		filter.CategoryIDs = append(filter.CategoryIDs, id)
+	}
+
+	for _, value := range queryList(c, "account_id") {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account_id"})
+			return
+		}
+		filter.AccountIDs = append(filter.AccountIDs, id)
+	}
+
+	if filter.MinAmountCents, err = parseInt64Query(c, "min_amount"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if filter.MaxAmountCents, err = parseInt64Query(c, "max_amount"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if value := c.Query("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+	}
+
+	page, err := h.usecase.GetAll(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, transactions)
+	c.JSON(http.StatusOK, page)
 }
 
 func (h *TransactionHandler) GetByID(c *gin.Context) {
@@ -74,19 +140,28 @@ func (h *TransactionHandler) Update(c *gin.Context) {
 	}
 
 	var transaction entity.Transaction
-	if err := c.ShouldBindJSON(&transaction); err != nil {
+	fields, err := bindJSONFields(c, &transaction)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	transaction.ID = id
 
-	if err := h.usecase.Update(c.Request.Context(), &transaction); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.usecase.Update(c.Request.Context(), &transaction, fields); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, transaction)
+	// Buscar transação completa com o cronograma reconciliado
+	updatedTransaction, err := h.usecase.GetByID(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	updatedTransaction.InstallmentChanges = transaction.InstallmentChanges
+
+	c.JSON(http.StatusOK, updatedTransaction)
 }
 
 func (h *TransactionHandler) Delete(c *gin.Context) {
@@ -97,13 +172,130 @@ func (h *TransactionHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.usecase.Delete(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "transaction deleted"})
 }
 
+type payTransactionRequest struct {
+	PaidAt          *time.Time `json:"paid_at"`
+	PaidAmountCents *int64     `json:"paid_amount_cents"`
+}
+
+func (h *TransactionHandler) Pay(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	// O corpo é opcional: sem ele, registra o valor previsto pago agora
+	var req payTransactionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	transaction, err := h.usecase.Pay(c.Request.Context(), id, req.PaidAt, req.PaidAmountCents)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
+
+func (h *TransactionHandler) Unpay(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	transaction, err := h.usecase.Unpay(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
+
+func (h *TransactionHandler) ListPayments(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	payments, err := h.usecase.ListPayments(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payments)
+}
+
+type addPaymentRequest struct {
+	AmountCents       int64      `json:"amount_cents" binding:"required"`
+	PaidAt            *time.Time `json:"paid_at"`
+	InstallmentNumber *int       `json:"installment_number"`
+	Notes             *string    `json:"notes"`
+}
+
+func (h *TransactionHandler) AddPayment(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req addPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payment := &entity.Payment{AmountCents: req.AmountCents, Notes: req.Notes}
+	if req.PaidAt != nil {
+		payment.PaidAt = *req.PaidAt
+	}
+
+	payment, err = h.usecase.AddPayment(c.Request.Context(), id, req.InstallmentNumber, payment)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, payment)
+}
+
+func (h *TransactionHandler) DeletePayment(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	paymentID, err := strconv.ParseInt(c.Param("payment"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payment id"})
+		return
+	}
+
+	if err := h.usecase.DeletePayment(c.Request.Context(), id, paymentID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "payment deleted"})
+}
+
 func (h *TransactionHandler) PayInstallment(c *gin.Context) {
 	transactionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
@@ -118,10 +310,134 @@ func (h *TransactionHandler) PayInstallment(c *gin.Context) {
 	}
 
 	if err := h.usecase.PayInstallment(c.Request.Context(), transactionID, installmentNumber); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "installment paid"})
 }
 
+func (h *TransactionHandler) UnpayInstallment(c *gin.Context) {
+	transactionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction id"})
+		return
+	}
+
+	installmentNumber, err := strconv.Atoi(c.Param("installment"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid installment number"})
+		return
+	}
+
+	if err := h.usecase.UnpayInstallment(c.Request.Context(), transactionID, installmentNumber); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "installment payment reverted"})
+}
+
+type rescheduleInstallmentRequest struct {
+	DueDate     *time.Time `json:"due_date"`
+	AmountCents *int64     `json:"amount_cents"`
+}
+
+func (h *TransactionHandler) RescheduleInstallment(c *gin.Context) {
+	transactionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction id"})
+		return
+	}
+
+	installmentNumber, err := strconv.Atoi(c.Param("installment"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid installment number"})
+		return
+	}
+
+	var req rescheduleInstallmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	installment, err := h.usecase.RescheduleInstallment(c.Request.Context(), transactionID, installmentNumber, req.DueDate, req.AmountCents)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, installment)
+}
+
+func (h *TransactionHandler) CancelInstallments(c *gin.Context) {
+	transactionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction id"})
+		return
+	}
+
+	cancelled, err := h.usecase.CancelInstallments(c.Request.Context(), transactionID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "installments cancelled", "cancelled": cancelled})
+}
+
+type payOffInstallmentsRequest struct {
+	DiscountCents int64 `json:"discount_cents"`
+}
+
+func (h *TransactionHandler) PayOffInstallments(c *gin.Context) {
+	transactionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction id"})
+		return
+	}
+
+	// O corpo é opcional: sem ele, quita sem desconto
+	var req payOffInstallmentsRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	result, err := h.usecase.PayOffInstallments(c.Request.Context(), transactionID, req.DiscountCents)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *TransactionHandler) MarkOverdue(c *gin.Context) {
+	result, err := h.usecase.MarkOverdue(c.Request.Context(), time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *TransactionHandler) PreviewInstallments(c *gin.Context) {
+	var transaction entity.Transaction
+	if err := c.ShouldBindJSON(&transaction); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan, err := h.usecase.PreviewInstallments(&transaction)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
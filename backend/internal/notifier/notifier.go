@@ -0,0 +1,55 @@
+package notifier
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+
+	"manager/internal/entity"
+)
+
+// Channel entrega uma notificação por um meio externo (e-mail, webhook...). A lista no
+// aplicativo não é um canal: toda notificação já fica gravada no banco.
+type Channel interface {
+	Send(ctx context.Context, notification *entity.Notification) error
+}
+
+// FromEnv monta os canais configurados por variáveis de ambiente, indexados pelo nome usado
+// nas regras de alerta. Canais sem configuração ficam de fora.
+func FromEnv() map[string]Channel {
+	channels := make(map[string]Channel)
+
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+
+		var to []string
+		for _, address := range strings.Split(os.Getenv("ALERT_EMAIL_TO"), ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				to = append(to, address)
+			}
+		}
+
+		if len(to) == 0 {
+			log.Println("SMTP_HOST is set but ALERT_EMAIL_TO is empty, email channel disabled")
+		} else {
+			channels[entity.NotificationChannelEmail] = &SMTPChannel{
+				Addr:     host + ":" + port,
+				Host:     host,
+				Username: os.Getenv("SMTP_USERNAME"),
+				Password: os.Getenv("SMTP_PASSWORD"),
+				From:     os.Getenv("SMTP_FROM"),
+				To:       to,
+			}
+		}
+	}
+
+	if url := os.Getenv("ALERT_WEBHOOK_URL"); url != "" {
+		channels[entity.NotificationChannelWebhook] = NewWebhookChannel(url)
+	}
+
+	return channels
+}
@@ -0,0 +1,50 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+
+	"manager/internal/entity"
+)
+
+// SMTPChannel envia a notificação por e-mail. Sem usuário, conecta sem autenticação, o que
+// permite usar um servidor local de testes (ex: MailHog ou Mailpit em localhost:1025).
+type SMTPChannel struct {
+	Addr     string
+	Host     string
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+func (c *SMTPChannel) Send(ctx context.Context, notification *entity.Notification) error {
+	var auth smtp.Auth
+	if c.Username != "" {
+		auth = smtp.PlainAuth("", c.Username, c.Password, c.Host)
+	}
+
+	from := c.From
+	if from == "" {
+		from = c.Username
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(c.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Title))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(notification.Message)
+	msg.WriteString("\r\n")
+
+	if err := smtp.SendMail(c.Addr, auth, from, c.To, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
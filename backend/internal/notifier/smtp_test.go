@@ -0,0 +1,140 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"manager/internal/entity"
+)
+
+// fakeSMTPServer aceita uma conexão SMTP sem TLS nem autenticação e devolve o remetente, os
+// destinatários e a mensagem recebidos
+type fakeSMTPServer struct {
+	listener net.Listener
+	done     chan smtpMessage
+}
+
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeSMTPServer{listener: listener, done: make(chan smtpMessage, 1)}
+	go server.serve()
+	return server
+}
+
+func (s *fakeSMTPServer) serve() {
+	c, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer c.Close()
+
+	r := bufio.NewReader(c)
+	reply := func(line string) { c.Write([]byte(line + "\r\n")) }
+
+	var msg smtpMessage
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			msg.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			msg.to = append(msg.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			msg.data = data.String()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			s.done <- msg
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPChannelSend(t *testing.T) {
+	server := newFakeSMTPServer(t)
+
+	channel := &SMTPChannel{
+		Addr: server.listener.Addr().String(),
+		Host: "127.0.0.1",
+		From: "alertas@example.com",
+		To:   []string{"ana@example.com", "bruno@example.com"},
+	}
+
+	notification := &entity.Notification{
+		Title:   "Orçamento de Alimentação em 80%",
+		Message: "Gasto de R$ 800,00 em 02/2025 de um orçamento de R$ 1.000,00 (restam R$ 200,00).",
+	}
+
+	if err := channel.Send(context.Background(), notification); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	msg := <-server.done
+	if msg.from != channel.From {
+		t.Errorf("from = %s, want %s", msg.from, channel.From)
+	}
+	if strings.Join(msg.to, ",") != "ana@example.com,bruno@example.com" {
+		t.Errorf("to = %v, want %v", msg.to, channel.To)
+	}
+	if !strings.Contains(msg.data, "Subject: =?utf-8?q?Or=C3=A7amento_de_Alimenta=C3=A7=C3=A3o_em_80%?=") {
+		t.Errorf("message has no encoded subject:\n%s", msg.data)
+	}
+	if !strings.Contains(msg.data, notification.Message) {
+		t.Errorf("message has no body:\n%s", msg.data)
+	}
+}
+
+func TestSMTPChannelSendUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	channel := &SMTPChannel{Addr: addr, Host: "127.0.0.1", From: "alertas@example.com", To: []string{"ana@example.com"}}
+	if err := channel.Send(context.Background(), &entity.Notification{Title: "test"}); err == nil {
+		t.Error("Send() error = nil, want error")
+	}
+}
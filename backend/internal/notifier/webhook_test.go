@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"manager/internal/entity"
+)
+
+func TestWebhookChannelSend(t *testing.T) {
+	ruleID := int64(7)
+	notification := &entity.Notification{
+		ID:        3,
+		RuleID:    &ruleID,
+		Type:      entity.AlertTypeBillDue,
+		Title:     "Aluguel vence em 10/02/2025",
+		Message:   "Aluguel: R$ 1.500,00 em aberto com vencimento em 10/02/2025.",
+		DedupeKey: "bill:7:42:0:2025-02-10",
+	}
+
+	var received entity.Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("content type = %s, want application/json", contentType)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookChannel(server.URL).Send(context.Background(), notification); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if received.ID != notification.ID || received.Title != notification.Title || received.Message != notification.Message ||
+		received.DedupeKey != notification.DedupeKey || received.RuleID == nil || *received.RuleID != ruleID {
+		t.Errorf("payload = %+v, want %+v", received, *notification)
+	}
+}
+
+func TestWebhookChannelSendErrorStatus(t *testing.T) {
+	for _, status := range []int{http.StatusMovedPermanently, http.StatusBadRequest, http.StatusInternalServerError} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		err := NewWebhookChannel(server.URL).Send(context.Background(), &entity.Notification{Title: "test"})
+		if err == nil {
+			t.Errorf("status %d: Send() error = nil, want error", status)
+		}
+		server.Close()
+	}
+}
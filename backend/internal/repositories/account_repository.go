@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"manager/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AccountRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAccountRepository(db *pgxpool.Pool) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+// accountSelect calcula o saldo de cada conta com o mesmo critério de GetTotalBalance, a partir
+// de ledger_entries: o saldo inicial mais o valor quitado de receitas e despesas da conta.
+// Transferências saem da conta de origem (account_id) e entram na de destino.
+const accountSelect = `
+	SELECT a.id, a.name, a.type, a.opening_balance_cents, a.currency, a.archived, a.closing_day, a.due_day,
+		a.opening_balance_cents + COALESCE(m.total, 0), a.created_at, a.updated_at
+	FROM accounts a
+	LEFT JOIN (
+		SELECT m.account_id, SUM(m.amount_cents) AS total
+		FROM (
+			SELECT e.account_id, CASE WHEN e.type = 'income' THEN e.paid_cents ELSE -e.paid_cents END AS amount_cents
+			FROM ledger_entries e
+			WHERE e.account_id IS NOT NULL
+			UNION ALL
+			SELECT e.destination_account_id, e.paid_cents
+			FROM ledger_entries e
+			WHERE e.type = 'transfer'
+		) m
+		GROUP BY m.account_id
+	) m ON m.account_id = a.id
+`
+
+func scanAccount(row pgx.Row) (*entity.Account, error) {
+	var a entity.Account
+	err := row.Scan(
+		&a.ID,
+		&a.Name,
+		&a.Type,
+		&a.OpeningBalanceCents,
+		&a.Currency,
+		&a.Archived,
+		&a.ClosingDay,
+		&a.DueDay,
+		&a.BalanceCents,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *AccountRepository) Create(ctx context.Context, account *entity.Account) error {
+	query := `
+		INSERT INTO accounts (name, type, opening_balance_cents, currency, archived, closing_day, due_day)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		account.Name,
+		account.Type,
+		account.OpeningBalanceCents,
+		account.Currency,
+		account.Archived,
+		account.ClosingDay,
+		account.DueDay,
+	).Scan(&account.ID, &account.CreatedAt, &account.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create account: %w", err)
+	}
+
+	account.BalanceCents = account.OpeningBalanceCents
+
+	return nil
+}
+
+// GetAll lista as contas com seus saldos; as arquivadas só são incluídas se solicitado
+func (r *AccountRepository) GetAll(ctx context.Context, includeArchived bool) ([]entity.Account, error) {
+	query := accountSelect + `
+		WHERE a.archived = false OR $1
+		ORDER BY a.name ASC
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := []entity.Account{}
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account: %w", err)
+		}
+		accounts = append(accounts, *account)
+	}
+
+	return accounts, rows.Err()
+}
+
+func (r *AccountRepository) GetByID(ctx context.Context, id int64) (*entity.Account, error) {
+	query := accountSelect + `
+		WHERE a.id = $1
+	`
+
+	account, err := scanAccount(conn(ctx, r.db).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("account %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	return account, nil
+}
+
+func (r *AccountRepository) Update(ctx context.Context, account *entity.Account) error {
+	query := `
+		UPDATE accounts
+		SET name = $1, type = $2, opening_balance_cents = $3, currency = $4, archived = $5,
+		    closing_day = $6, due_day = $7, updated_at = NOW()
+		WHERE id = $8
+		RETURNING updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		account.Name,
+		account.Type,
+		account.OpeningBalanceCents,
+		account.Currency,
+		account.Archived,
+		account.ClosingDay,
+		account.DueDay,
+		account.ID,
+	).Scan(&account.UpdatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("account %d: %w", account.ID, entity.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AccountRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM accounts WHERE id = $1`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	return nil
+}
+
+// CountTransactions retorna quantas transações estão vinculadas à conta
+func (r *AccountRepository) CountTransactions(ctx context.Context, id int64) (int64, error) {
+	query := `SELECT COUNT(*) FROM transactions WHERE account_id = $1 OR destination_account_id = $1`
+
+	var count int64
+	if err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count account transactions: %w", err)
+	}
+
+	return count, nil
+}
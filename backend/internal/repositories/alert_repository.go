@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"manager/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AlertRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAlertRepository(db *pgxpool.Pool) *AlertRepository {
+	return &AlertRepository{db: db}
+}
+
+const alertRuleColumns = `id, name, type, threshold_percent, category_id, threshold_cents, account_id, days_ahead, channels, enabled, created_at, updated_at`
+
+func scanAlertRule(row pgx.Row) (*entity.AlertRule, error) {
+	var rule entity.AlertRule
+	var enabled bool
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Type,
+		&rule.ThresholdPercent,
+		&rule.CategoryID,
+		&rule.ThresholdCents,
+		&rule.AccountID,
+		&rule.DaysAhead,
+		&rule.Channels,
+		&enabled,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rule.Enabled = &enabled
+	return &rule, nil
+}
+
+func (r *AlertRepository) Create(ctx context.Context, rule *entity.AlertRule) error {
+	query := `
+		INSERT INTO alert_rules (name, type, threshold_percent, category_id, threshold_cents, account_id, days_ahead, channels, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		rule.Name,
+		rule.Type,
+		rule.ThresholdPercent,
+		rule.CategoryID,
+		rule.ThresholdCents,
+		rule.AccountID,
+		rule.DaysAhead,
+		rule.Channels,
+		*rule.Enabled,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create alert rule: %w", err)
+	}
+
+	return nil
+}
+
+// GetAll lista as regras; com onlyEnabled, apenas as habilitadas
+func (r *AlertRepository) GetAll(ctx context.Context, onlyEnabled bool) ([]entity.AlertRule, error) {
+	query := `SELECT ` + alertRuleColumns + ` FROM alert_rules`
+	if onlyEnabled {
+		query += ` WHERE enabled = true`
+	}
+	query += ` ORDER BY id`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []entity.AlertRule{}
+	for rows.Next() {
+		rule, err := scanAlertRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert rule: %w", err)
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, rows.Err()
+}
+
+func (r *AlertRepository) GetByID(ctx context.Context, id int64) (*entity.AlertRule, error) {
+	query := `SELECT ` + alertRuleColumns + ` FROM alert_rules WHERE id = $1`
+
+	rule, err := scanAlertRule(conn(ctx, r.db).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("alert rule %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (r *AlertRepository) Update(ctx context.Context, rule *entity.AlertRule) error {
+	query := `
+		UPDATE alert_rules
+		SET name = $1, type = $2, threshold_percent = $3, category_id = $4, threshold_cents = $5,
+			account_id = $6, days_ahead = $7, channels = $8, enabled = $9, updated_at = NOW()
+		WHERE id = $10
+		RETURNING created_at, updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		rule.Name,
+		rule.Type,
+		rule.ThresholdPercent,
+		rule.CategoryID,
+		rule.ThresholdCents,
+		rule.AccountID,
+		rule.DaysAhead,
+		rule.Channels,
+		*rule.Enabled,
+		rule.ID,
+	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("alert rule %d: %w", rule.ID, entity.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update alert rule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AlertRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM alert_rules WHERE id = $1`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+
+	return nil
+}
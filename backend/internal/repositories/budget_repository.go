@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"manager/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BudgetRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBudgetRepository(db *pgxpool.Pool) *BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+const budgetColumns = `id, category_id, month, limit_cents, rollover, created_at, updated_at`
+
+func scanBudget(row pgx.Row) (*entity.Budget, error) {
+	var b entity.Budget
+	err := row.Scan(
+		&b.ID,
+		&b.CategoryID,
+		&b.Month,
+		&b.LimitCents,
+		&b.Rollover,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *BudgetRepository) Create(ctx context.Context, budget *entity.Budget) error {
+	query := `
+		INSERT INTO budgets (category_id, month, limit_cents, rollover)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		budget.CategoryID,
+		budget.Month,
+		budget.LimitCents,
+		budget.Rollover,
+	).Scan(&budget.ID, &budget.CreatedAt, &budget.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	return nil
+}
+
+// GetByMonth lista os orçamentos do mês (primeiro dia do mês)
+func (r *BudgetRepository) GetByMonth(ctx context.Context, month time.Time) ([]entity.Budget, error) {
+	return r.list(ctx, `SELECT `+budgetColumns+` FROM budgets WHERE month = $1 ORDER BY category_id`, month)
+}
+
+// GetByCategory lista os orçamentos da categoria até o mês informado, do mais recente para o mais antigo
+func (r *BudgetRepository) GetByCategory(ctx context.Context, categoryID int64, until time.Time) ([]entity.Budget, error) {
+	return r.list(ctx, `SELECT `+budgetColumns+` FROM budgets WHERE category_id = $1 AND month <= $2 ORDER BY month DESC`, categoryID, until)
+}
+
+func (r *BudgetRepository) list(ctx context.Context, query string, args ...any) ([]entity.Budget, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+	defer rows.Close()
+
+	budgets := []entity.Budget{}
+	for rows.Next() {
+		budget, err := scanBudget(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan budget: %w", err)
+		}
+		budgets = append(budgets, *budget)
+	}
+
+	return budgets, rows.Err()
+}
+
+func (r *BudgetRepository) GetByID(ctx context.Context, id int64) (*entity.Budget, error) {
+	query := `SELECT ` + budgetColumns + ` FROM budgets WHERE id = $1`
+
+	budget, err := scanBudget(conn(ctx, r.db).QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("budget %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	return budget, nil
+}
+
+// Exists indica se já há orçamento para a categoria no mês, ignorando o orçamento exceptID
+func (r *BudgetRepository) Exists(ctx context.Context, categoryID int64, month time.Time, exceptID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM budgets WHERE category_id = $1 AND month = $2 AND id <> $3)`
+
+	var exists bool
+	if err := conn(ctx, r.db).QueryRow(ctx, query, categoryID, month, exceptID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check budget: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *BudgetRepository) Update(ctx context.Context, budget *entity.Budget) error {
+	query := `
+		UPDATE budgets
+		SET category_id = $1, month = $2, limit_cents = $3, rollover = $4, updated_at = NOW()
+		WHERE id = $5
+		RETURNING created_at, updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		budget.CategoryID,
+		budget.Month,
+		budget.LimitCents,
+		budget.Rollover,
+		budget.ID,
+	).Scan(&budget.CreatedAt, &budget.UpdatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("budget %d: %w", budget.ID, entity.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BudgetRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM budgets WHERE id = $1`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	return nil
+}
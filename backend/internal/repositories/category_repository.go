@@ -19,12 +19,13 @@ func NewCategoryRepository(db *pgxpool.Pool) *CategoryRepository {
 
 func (r *CategoryRepository) Create(ctx context.Context, category *entity.Category) error {
 	query := `
-		INSERT INTO categories (name, description, color, icon)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO categories (parent_id, name, description, color, icon)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query,
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		category.ParentID,
 		category.Name,
 		category.Description,
 		category.Color,
@@ -38,14 +39,26 @@ func (r *CategoryRepository) Create(ctx context.Context, category *entity.Catego
 	return nil
 }
 
+// GetAll lista as categorias em árvore: as de primeiro nível, com as subcategorias em children,
+// em ordem alfabética em cada nível
 func (r *CategoryRepository) GetAll(ctx context.Context) ([]entity.Category, error) {
+	categories, err := r.GetAllFlat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCategoryTree(categories), nil
+}
+
+// GetAllFlat lista todas as categorias, de qualquer nível, em ordem alfabética
+func (r *CategoryRepository) GetAllFlat(ctx context.Context) ([]entity.Category, error) {
 	query := `
-		SELECT id, name, description, color, icon, created_at, updated_at
+		SELECT id, parent_id, name, description, color, icon, created_at, updated_at
 		FROM categories
 		ORDER BY name ASC
 	`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := conn(ctx, r.db).Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get categories: %w", err)
 	}
@@ -56,6 +69,7 @@ func (r *CategoryRepository) GetAll(ctx context.Context) ([]entity.Category, err
 		var cat entity.Category
 		err := rows.Scan(
 			&cat.ID,
+			&cat.ParentID,
 			&cat.Name,
 			&cat.Description,
 			&cat.Color,
@@ -72,16 +86,46 @@ func (r *CategoryRepository) GetAll(ctx context.Context) ([]entity.Category, err
 	return categories, nil
 }
 
+// buildCategoryTree aninha as categorias sob as respectivas categorias pai, mantendo a ordem.
+// Categorias cujo pai não está na lista ficam no primeiro nível.
+func buildCategoryTree(categories []entity.Category) []entity.Category {
+	known := make(map[int64]bool, len(categories))
+	for _, category := range categories {
+		known[category.ID] = true
+	}
+
+	childrenOf := make(map[int64][]entity.Category)
+	var roots []entity.Category
+	for _, category := range categories {
+		if category.ParentID != nil && known[*category.ParentID] {
+			childrenOf[*category.ParentID] = append(childrenOf[*category.ParentID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	var attach func(nodes []entity.Category) []entity.Category
+	attach = func(nodes []entity.Category) []entity.Category {
+		for i := range nodes {
+			nodes[i].Children = attach(childrenOf[nodes[i].ID])
+		}
+		return nodes
+	}
+
+	return attach(roots)
+}
+
 func (r *CategoryRepository) GetByID(ctx context.Context, id int64) (*entity.Category, error) {
 	query := `
-		SELECT id, name, description, color, icon, created_at, updated_at
+		SELECT id, parent_id, name, description, color, icon, created_at, updated_at
 		FROM categories
 		WHERE id = $1
 	`
 
 	var category entity.Category
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&category.ID,
+		&category.ParentID,
 		&category.Name,
 		&category.Description,
 		&category.Color,
@@ -100,12 +144,13 @@ func (r *CategoryRepository) GetByID(ctx context.Context, id int64) (*entity.Cat
 func (r *CategoryRepository) Update(ctx context.Context, category *entity.Category) error {
 	query := `
 		UPDATE categories
-		SET name = $1, description = $2, color = $3, icon = $4, updated_at = NOW()
-		WHERE id = $5
+		SET parent_id = $1, name = $2, description = $3, color = $4, icon = $5, updated_at = NOW()
+		WHERE id = $6
 		RETURNING updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query,
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		category.ParentID,
 		category.Name,
 		category.Description,
 		category.Color,
@@ -123,7 +168,7 @@ func (r *CategoryRepository) Update(ctx context.Context, category *entity.Catego
 func (r *CategoryRepository) Delete(ctx context.Context, id int64) error {
 	query := `DELETE FROM categories WHERE id = $1`
 
-	_, err := r.db.Exec(ctx, query, id)
+	_, err := conn(ctx, r.db).Exec(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete category: %w", err)
 	}
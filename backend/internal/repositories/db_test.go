@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"manager/internal/entity"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// testDB conecta ao banco informado em TEST_DATABASE_URL, que deve ter as migrations aplicadas.
+// Sem a variável, o teste é pulado.
+func testDB(tb testing.TB) *pgxpool.Pool {
+	tb.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		tb.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := pgxpool.New(context.Background(), dsn)
+	if err != nil {
+		tb.Fatalf("failed to connect to test database: %v", err)
+	}
+	tb.Cleanup(db.Close)
+
+	return db
+}
+
+// rollbackContext abre uma transação desfeita ao fim do teste e a propaga pelo contexto, como o
+// UnitOfWork, para que os dados criados pelo teste não fiquem no banco
+func rollbackContext(tb testing.TB, db *pgxpool.Pool) context.Context {
+	tb.Helper()
+
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		tb.Fatalf("failed to begin test transaction: %v", err)
+	}
+	tb.Cleanup(func() { _ = tx.Rollback(context.Background()) })
+
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// createTestTransaction grava a transação e, se installments não for vazio, as parcelas com os
+// valores e status informados, com vencimentos mensais a partir de due_date
+func createTestTransaction(tb testing.TB, ctx context.Context, repo *TransactionRepository, transaction entity.Transaction, installments ...entity.Installment) *entity.Transaction {
+	tb.Helper()
+
+	if transaction.Title == "" {
+		transaction.Title = "test"
+	}
+	if transaction.Status == "" {
+		transaction.Status = entity.TransactionStatusPending
+	}
+	if len(installments) > 0 {
+		transaction.IsInstallment = true
+		transaction.TotalInstallments = len(installments)
+	}
+
+	if err := repo.Create(ctx, &transaction); err != nil {
+		tb.Fatal(err)
+	}
+
+	for i := range installments {
+		installment := &installments[i]
+		installment.TransactionID = transaction.ID
+		if installment.InstallmentNumber == 0 {
+			installment.InstallmentNumber = i + 1
+		}
+		if installment.DueDate.IsZero() {
+			installment.DueDate = transaction.DueDate.AddDate(0, i, 0)
+		}
+		if installment.Status == "" {
+			installment.Status = entity.InstallmentStatusPending
+		}
+		if err := repo.CreateInstallment(ctx, installment); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	transaction.Installments = installments
+
+	return &transaction
+}
+
+func testDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"manager/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type GoalRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewGoalRepository(db *pgxpool.Pool) *GoalRepository {
+	return &GoalRepository{db: db}
+}
+
+const goalSelect = `
+	SELECT g.id, g.name, g.target_cents, g.target_date, g.account_id,
+		COALESCE((SELECT SUM(c.amount_cents) FROM goal_contributions c WHERE c.goal_id = g.id), 0),
+		g.created_at, g.updated_at
+	FROM goals g
+`
+
+func scanGoal(row pgx.Row) (*entity.Goal, error) {
+	var g entity.Goal
+	err := row.Scan(
+		&g.ID,
+		&g.Name,
+		&g.TargetCents,
+		&g.TargetDate,
+		&g.AccountID,
+		&g.SavedCents,
+		&g.CreatedAt,
+		&g.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *GoalRepository) Create(ctx context.Context, goal *entity.Goal) error {
+	query := `
+		INSERT INTO goals (name, target_cents, target_date, account_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		goal.Name,
+		goal.TargetCents,
+		goal.TargetDate,
+		goal.AccountID,
+	).Scan(&goal.ID, &goal.CreatedAt, &goal.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create goal: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GoalRepository) GetAll(ctx context.Context) ([]entity.Goal, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, goalSelect+` ORDER BY g.target_date NULLS LAST, g.id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goals: %w", err)
+	}
+	defer rows.Close()
+
+	goals := []entity.Goal{}
+	for rows.Next() {
+		goal, err := scanGoal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan goal: %w", err)
+		}
+		goals = append(goals, *goal)
+	}
+
+	return goals, rows.Err()
+}
+
+func (r *GoalRepository) GetByID(ctx context.Context, id int64) (*entity.Goal, error) {
+	goal, err := scanGoal(conn(ctx, r.db).QueryRow(ctx, goalSelect+` WHERE g.id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("goal %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goal: %w", err)
+	}
+
+	return goal, nil
+}
+
+func (r *GoalRepository) Update(ctx context.Context, goal *entity.Goal) error {
+	query := `
+		UPDATE goals
+		SET name = $1, target_cents = $2, target_date = $3, account_id = $4, updated_at = NOW()
+		WHERE id = $5
+		RETURNING created_at, updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		goal.Name,
+		goal.TargetCents,
+		goal.TargetDate,
+		goal.AccountID,
+		goal.ID,
+	).Scan(&goal.CreatedAt, &goal.UpdatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("goal %d: %w", goal.ID, entity.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update goal: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GoalRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM goals WHERE id = $1`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GoalRepository) CreateContribution(ctx context.Context, contribution *entity.GoalContribution) error {
+	query := `
+		INSERT INTO goal_contributions (goal_id, transaction_id, amount_cents, contributed_at, notes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		contribution.GoalID,
+		contribution.TransactionID,
+		contribution.AmountCents,
+		contribution.ContributedAt,
+		contribution.Notes,
+	).Scan(&contribution.ID, &contribution.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create goal contribution: %w", err)
+	}
+
+	return nil
+}
+
+// GetContributions lista os aportes da meta em ordem cronológica
+func (r *GoalRepository) GetContributions(ctx context.Context, goalID int64) ([]entity.GoalContribution, error) {
+	query := `
+		SELECT id, goal_id, transaction_id, amount_cents, contributed_at, notes, created_at
+		FROM goal_contributions
+		WHERE goal_id = $1
+		ORDER BY contributed_at, id
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goal contributions: %w", err)
+	}
+	defer rows.Close()
+
+	contributions := []entity.GoalContribution{}
+	for rows.Next() {
+		var c entity.GoalContribution
+		if err := rows.Scan(&c.ID, &c.GoalID, &c.TransactionID, &c.AmountCents, &c.ContributedAt, &c.Notes, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan goal contribution: %w", err)
+		}
+		contributions = append(contributions, c)
+	}
+
+	return contributions, rows.Err()
+}
+
+// HasTransaction indica se a transação já foi registrada como aporte da meta
+func (r *GoalRepository) HasTransaction(ctx context.Context, goalID int64, transactionID int64) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM goal_contributions WHERE goal_id = $1 AND transaction_id = $2)`
+
+	var exists bool
+	if err := conn(ctx, r.db).QueryRow(ctx, query, goalID, transactionID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check goal contribution: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *GoalRepository) DeleteContribution(ctx context.Context, goalID int64, id int64) error {
+	query := `DELETE FROM goal_contributions WHERE goal_id = $1 AND id = $2`
+
+	tag, err := conn(ctx, r.db).Exec(ctx, query, goalID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete goal contribution: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("goal contribution %d: %w", id, entity.ErrNotFound)
+	}
+
+	return nil
+}
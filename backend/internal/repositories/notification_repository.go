@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"manager/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type NotificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationRepository(db *pgxpool.Pool) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// CreateOnce grava a notificação se ainda não houver outra com a mesma dedupe_key.
+// Retorna false quando a notificação já existia.
+func (r *NotificationRepository) CreateOnce(ctx context.Context, notification *entity.Notification) (bool, error) {
+	query := `
+		INSERT INTO notifications (rule_id, type, title, message, dedupe_key)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (dedupe_key) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		notification.RuleID,
+		notification.Type,
+		notification.Title,
+		notification.Message,
+		notification.DedupeKey,
+	).Scan(&notification.ID, &notification.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetAll lista as notificações mais recentes primeiro; com onlyUnread, apenas as não lidas
+func (r *NotificationRepository) GetAll(ctx context.Context, onlyUnread bool, limit int) ([]entity.Notification, error) {
+	query := `SELECT id, rule_id, type, title, message, dedupe_key, read_at, created_at FROM notifications`
+	if onlyUnread {
+		query += ` WHERE read_at IS NULL`
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT $1`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []entity.Notification{}
+	for rows.Next() {
+		var n entity.Notification
+		err := rows.Scan(&n.ID, &n.RuleID, &n.Type, &n.Title, &n.Message, &n.DedupeKey, &n.ReadAt, &n.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	return notifications, rows.Err()
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, id int64) error {
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, NOW()) WHERE id = $1`
+
+	tag, err := conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("notification %d: %w", id, entity.ErrNotFound)
+	}
+
+	return nil
+}
+
+// MarkAllRead marca como lidas todas as notificações não lidas e retorna quantas foram alteradas
+func (r *NotificationRepository) MarkAllRead(ctx context.Context) (int64, error) {
+	query := `UPDATE notifications SET read_at = NOW() WHERE read_at IS NULL`
+
+	tag, err := conn(ctx, r.db).Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"testing"
+
+	"manager/internal/entity"
+)
+
+func TestNotificationCreateOnceDedupe(t *testing.T) {
+	db := testDB(t)
+	ctx := rollbackContext(t, db)
+	repo := NewNotificationRepository(db)
+
+	notification := func() *entity.Notification {
+		return &entity.Notification{
+			Type:      entity.AlertTypeBudgetThreshold,
+			Title:     "Orçamento de Alimentação em 80%",
+			Message:   "Gasto de R$ 800,00 em 02/2025.",
+			DedupeKey: "budget:test:80:5:2025-02",
+		}
+	}
+
+	first := notification()
+	created, err := repo.CreateOnce(ctx, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created || first.ID == 0 {
+		t.Fatalf("first notification not created: created = %v, id = %d", created, first.ID)
+	}
+
+	second := notification()
+	created, err = repo.CreateOnce(ctx, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created {
+		t.Errorf("second notification with the same dedupe_key was created (id %d)", second.ID)
+	}
+
+	nextMonth := notification()
+	nextMonth.DedupeKey = "budget:test:80:5:2025-03"
+	if created, err = repo.CreateOnce(ctx, nextMonth); err != nil || !created {
+		t.Errorf("notification for the next month: created = %v, err = %v", created, err)
+	}
+}
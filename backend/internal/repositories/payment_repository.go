@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"manager/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PaymentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPaymentRepository(db *pgxpool.Pool) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+const paymentColumns = `
+	p.id, p.transaction_id, p.installment_id, ti.installment_number, p.amount_cents, p.paid_at, p.notes, p.created_at
+`
+
+func scanPayment(row pgx.Row) (*entity.Payment, error) {
+	var p entity.Payment
+	err := row.Scan(
+		&p.ID,
+		&p.TransactionID,
+		&p.InstallmentID,
+		&p.InstallmentNumber,
+		&p.AmountCents,
+		&p.PaidAt,
+		&p.Notes,
+		&p.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *PaymentRepository) Create(ctx context.Context, payment *entity.Payment) error {
+	query := `
+		INSERT INTO payments (transaction_id, installment_id, amount_cents, paid_at, notes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		payment.TransactionID,
+		payment.InstallmentID,
+		payment.AmountCents,
+		payment.PaidAt,
+		payment.Notes,
+	).Scan(&payment.ID, &payment.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTransactionID lista os pagamentos da transação e de suas parcelas, em ordem cronológica
+func (r *PaymentRepository) GetByTransactionID(ctx context.Context, transactionID int64) ([]entity.Payment, error) {
+	query := `
+		SELECT ` + paymentColumns + `
+		FROM payments p
+		LEFT JOIN transaction_installments ti ON p.installment_id = ti.id
+		WHERE p.transaction_id = $1
+		ORDER BY p.paid_at, p.id
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []entity.Payment{}
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment: %w", err)
+		}
+		payments = append(payments, *payment)
+	}
+
+	return payments, rows.Err()
+}
+
+func (r *PaymentRepository) GetByID(ctx context.Context, transactionID int64, id int64) (*entity.Payment, error) {
+	query := `
+		SELECT ` + paymentColumns + `
+		FROM payments p
+		LEFT JOIN transaction_installments ti ON p.installment_id = ti.id
+		WHERE p.transaction_id = $1 AND p.id = $2
+	`
+
+	payment, err := scanPayment(conn(ctx, r.db).QueryRow(ctx, query, transactionID, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("payment %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+func (r *PaymentRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM payments WHERE id = $1`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete payment: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByTarget remove os pagamentos da transação simples (installmentID nil) ou de uma parcela
+func (r *PaymentRepository) DeleteByTarget(ctx context.Context, transactionID int64, installmentID *int64) error {
+	query := `DELETE FROM payments WHERE transaction_id = $1 AND installment_id IS NOT DISTINCT FROM $2`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, transactionID, installmentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete payments: %w", err)
+	}
+
+	return nil
+}
+
+// SumByTarget retorna o total pago e a data do último pagamento da transação simples
+// (installmentID nil) ou de uma parcela
+func (r *PaymentRepository) SumByTarget(ctx context.Context, transactionID int64, installmentID *int64) (int64, *time.Time, error) {
+	query := `
+		SELECT COALESCE(SUM(amount_cents), 0), MAX(paid_at)
+		FROM payments
+		WHERE transaction_id = $1 AND installment_id IS NOT DISTINCT FROM $2
+	`
+
+	var total int64
+	var lastPaidAt *time.Time
+	err := conn(ctx, r.db).QueryRow(ctx, query, transactionID, installmentID).Scan(&total, &lastPaidAt)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to sum payments: %w", err)
+	}
+
+	return total, lastPaidAt, nil
+}
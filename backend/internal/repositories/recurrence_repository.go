@@ -0,0 +1,254 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"manager/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RecurrenceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRecurrenceRepository(db *pgxpool.Pool) *RecurrenceRepository {
+	return &RecurrenceRepository{db: db}
+}
+
+const recurrenceColumns = `
+	id, title, description, amount_cents, type, category_id, frequency, interval_count,
+	day_of_month, start_date, end_date, max_occurrences, occurrences_count, next_due_date,
+	status, created_at, updated_at
+`
+
+func scanRecurrence(row pgx.Row) (*entity.Recurrence, error) {
+	var r entity.Recurrence
+	err := row.Scan(
+		&r.ID,
+		&r.Title,
+		&r.Description,
+		&r.AmountCents,
+		&r.Type,
+		&r.CategoryID,
+		&r.Frequency,
+		&r.Interval,
+		&r.DayOfMonth,
+		&r.StartDate,
+		&r.EndDate,
+		&r.MaxOccurrences,
+		&r.OccurrencesCount,
+		&r.NextDueDate,
+		&r.Status,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (r *RecurrenceRepository) Create(ctx context.Context, recurrence *entity.Recurrence) error {
+	query := `
+		INSERT INTO recurrences (title, description, amount_cents, type, category_id, frequency, interval_count,
+			day_of_month, start_date, end_date, max_occurrences, occurrences_count, next_due_date, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		recurrence.Title,
+		recurrence.Description,
+		recurrence.AmountCents,
+		recurrence.Type,
+		recurrence.CategoryID,
+		recurrence.Frequency,
+		recurrence.Interval,
+		recurrence.DayOfMonth,
+		recurrence.StartDate,
+		recurrence.EndDate,
+		recurrence.MaxOccurrences,
+		recurrence.OccurrencesCount,
+		recurrence.NextDueDate,
+		recurrence.Status,
+	).Scan(&recurrence.ID, &recurrence.CreatedAt, &recurrence.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create recurrence: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecurrenceRepository) GetAll(ctx context.Context) ([]entity.Recurrence, error) {
+	query := `SELECT ` + recurrenceColumns + ` FROM recurrences ORDER BY next_due_date ASC, id ASC`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	var recurrences []entity.Recurrence
+	for rows.Next() {
+		rec, err := scanRecurrence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recurrence: %w", err)
+		}
+		recurrences = append(recurrences, *rec)
+	}
+
+	return recurrences, nil
+}
+
+func (r *RecurrenceRepository) GetByID(ctx context.Context, id int64) (*entity.Recurrence, error) {
+	query := `SELECT ` + recurrenceColumns + ` FROM recurrences WHERE id = $1`
+
+	rec, err := scanRecurrence(conn(ctx, r.db).QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurrence: %w", err)
+	}
+
+	return rec, nil
+}
+
+// GetDue retorna as séries ativas com próxima ocorrência até a data informada
+func (r *RecurrenceRepository) GetDue(ctx context.Context, until time.Time) ([]entity.Recurrence, error) {
+	query := `SELECT ` + recurrenceColumns + `
+		FROM recurrences
+		WHERE status = 'active' AND next_due_date <= $1
+		ORDER BY next_due_date ASC, id ASC
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	var recurrences []entity.Recurrence
+	for rows.Next() {
+		rec, err := scanRecurrence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recurrence: %w", err)
+		}
+		recurrences = append(recurrences, *rec)
+	}
+
+	return recurrences, nil
+}
+
+func (r *RecurrenceRepository) Update(ctx context.Context, recurrence *entity.Recurrence) error {
+	query := `
+		UPDATE recurrences
+		SET title = $1, description = $2, amount_cents = $3, type = $4, category_id = $5,
+		    frequency = $6, interval_count = $7, day_of_month = $8, end_date = $9,
+		    max_occurrences = $10, next_due_date = $11, status = $12, updated_at = NOW()
+		WHERE id = $13
+		RETURNING updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		recurrence.Title,
+		recurrence.Description,
+		recurrence.AmountCents,
+		recurrence.Type,
+		recurrence.CategoryID,
+		recurrence.Frequency,
+		recurrence.Interval,
+		recurrence.DayOfMonth,
+		recurrence.EndDate,
+		recurrence.MaxOccurrences,
+		recurrence.NextDueDate,
+		recurrence.Status,
+		recurrence.ID,
+	).Scan(&recurrence.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to update recurrence: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecurrenceRepository) UpdateStatus(ctx context.Context, id int64, status entity.RecurrenceStatus, nextDueDate time.Time) error {
+	query := `
+		UPDATE recurrences
+		SET status = $1, next_due_date = $2, updated_at = NOW()
+		WHERE id = $3
+	`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, status, nextDueDate, id)
+	if err != nil {
+		return fmt.Errorf("failed to update recurrence status: %w", err)
+	}
+
+	return nil
+}
+
+// GenerateOccurrence cria a transação da ocorrência atual e avança a série em um único comando.
+// A condição sobre next_due_date torna a operação idempotente: se outra execução já avançou
+// a série, nada é inserido e o retorno é false.
+func (r *RecurrenceRepository) GenerateOccurrence(ctx context.Context, id int64, dueDate, nextDueDate time.Time, status entity.RecurrenceStatus) (bool, error) {
+	query := `
+		WITH due AS (
+			SELECT id, title, description, amount_cents, type, category_id, next_due_date
+			FROM recurrences
+			WHERE id = $1 AND next_due_date = $2 AND status = 'active'
+			FOR UPDATE
+		), inserted AS (
+			INSERT INTO transactions (title, description, amount_cents, type, category_id, due_date, is_recurring, recurrence_id, is_installment, total_installments, status)
+			SELECT title, description, amount_cents, type, category_id, next_due_date, true, id, false, 1, 'pending'
+			FROM due
+			ON CONFLICT (recurrence_id, due_date) DO NOTHING
+		)
+		UPDATE recurrences r
+		SET next_due_date = $3, occurrences_count = r.occurrences_count + 1, status = $4, updated_at = NOW()
+		FROM due
+		WHERE r.id = due.id
+		RETURNING r.id
+	`
+
+	var updatedID int64
+	err := conn(ctx, r.db).QueryRow(ctx, query, id, dueDate, nextDueDate, status).Scan(&updatedID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to generate recurrence occurrence: %w", err)
+	}
+
+	return true, nil
+}
+
+// SkipOccurrence avança a série sem gerar a transação da ocorrência atual
+func (r *RecurrenceRepository) SkipOccurrence(ctx context.Context, id int64, dueDate, nextDueDate time.Time, status entity.RecurrenceStatus) (bool, error) {
+	query := `
+		UPDATE recurrences
+		SET next_due_date = $3, occurrences_count = occurrences_count + 1, status = $4, updated_at = NOW()
+		WHERE id = $1 AND next_due_date = $2 AND status != 'finished'
+	`
+
+	tag, err := conn(ctx, r.db).Exec(ctx, query, id, dueDate, nextDueDate, status)
+	if err != nil {
+		return false, fmt.Errorf("failed to skip recurrence occurrence: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *RecurrenceRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM recurrences WHERE id = $1`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurrence: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"manager/internal/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StatementRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewStatementRepository(db *pgxpool.Pool) *StatementRepository {
+	return &StatementRepository{db: db}
+}
+
+// statementItems reúne as compras simples e as parcelas lançadas em faturas.
+// Receitas no cartão (estornos) abatem o total da fatura.
+const statementItems = `
+	SELECT t.statement_id, t.id AS transaction_id, NULL::int AS installment_number, NULL::int AS total_installments,
+		t.title, t.type, COALESCE(t.paid_amount_cents, t.amount_cents) AS amount_cents, t.due_date AS date,
+		t.status, t.status = 'paid' AS paid
+	FROM transactions t
+	WHERE t.statement_id IS NOT NULL AND t.is_installment = false AND t.status != 'cancelled'
+	UNION ALL
+	SELECT ti.statement_id, t.id, ti.installment_number, t.total_installments,
+		t.title, t.type, ti.amount_cents, ti.due_date,
+		ti.status, ti.status = 'paid'
+	FROM transaction_installments ti
+	INNER JOIN transactions t ON ti.transaction_id = t.id
+	WHERE ti.statement_id IS NOT NULL AND ti.status != 'cancelled'
+`
+
+const statementSelect = `
+	WITH items AS (` + statementItems + `)
+	SELECT s.id, s.account_id, s.closing_date, s.due_date,
+		CASE
+			WHEN s.paid_at IS NOT NULL THEN 'paid'
+			WHEN s.closing_date <= CURRENT_DATE THEN 'closed'
+			ELSE 'open'
+		END,
+		s.paid_at, s.payment_transaction_id,
+		COALESCE(SUM(CASE WHEN i.type = 'income' THEN -i.amount_cents ELSE i.amount_cents END), 0),
+		COALESCE(SUM(CASE WHEN i.type = 'income' THEN -i.amount_cents ELSE i.amount_cents END) FILTER (WHERE i.paid), 0),
+		COUNT(i.transaction_id),
+		s.created_at, s.updated_at
+	FROM statements s
+	LEFT JOIN items i ON i.statement_id = s.id
+`
+
+func scanStatement(row pgx.Row) (*entity.Statement, error) {
+	var s entity.Statement
+	err := row.Scan(
+		&s.ID,
+		&s.AccountID,
+		&s.ClosingDate,
+		&s.DueDate,
+		&s.Status,
+		&s.PaidAt,
+		&s.PaymentTransactionID,
+		&s.TotalCents,
+		&s.PaidCents,
+		&s.ItemCount,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetOrCreate retorna a fatura do cartão com a data de fechamento informada, criando-a se necessário,
+// e se ela já foi paga
+func (r *StatementRepository) GetOrCreate(ctx context.Context, accountID int64, closingDate, dueDate time.Time) (int64, bool, error) {
+	query := `
+		INSERT INTO statements (account_id, closing_date, due_date)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (account_id, closing_date) DO UPDATE SET due_date = EXCLUDED.due_date
+		RETURNING id, paid_at IS NOT NULL
+	`
+
+	var id int64
+	var paid bool
+	if err := conn(ctx, r.db).QueryRow(ctx, query, accountID, closingDate, dueDate).Scan(&id, &paid); err != nil {
+		return 0, false, fmt.Errorf("failed to get or create statement: %w", err)
+	}
+
+	return id, paid, nil
+}
+
+// GetByAccount lista as faturas do cartão que têm lançamentos ou já foram pagas, da mais recente para a mais antiga
+func (r *StatementRepository) GetByAccount(ctx context.Context, accountID int64) ([]entity.Statement, error) {
+	query := statementSelect + `
+		WHERE s.account_id = $1
+		GROUP BY s.id
+		HAVING COUNT(i.transaction_id) > 0 OR s.paid_at IS NOT NULL
+		ORDER BY s.closing_date DESC
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statements: %w", err)
+	}
+	defer rows.Close()
+
+	statements := []entity.Statement{}
+	for rows.Next() {
+		statement, err := scanStatement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan statement: %w", err)
+		}
+		statements = append(statements, *statement)
+	}
+
+	return statements, rows.Err()
+}
+
+func (r *StatementRepository) GetByID(ctx context.Context, accountID int64, id int64) (*entity.Statement, error) {
+	query := statementSelect + `
+		WHERE s.account_id = $1 AND s.id = $2
+		GROUP BY s.id
+	`
+
+	statement, err := scanStatement(conn(ctx, r.db).QueryRow(ctx, query, accountID, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("statement %d: %w", id, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement: %w", err)
+	}
+
+	return statement, nil
+}
+
+// GetItems lista os lançamentos da fatura em ordem cronológica
+func (r *StatementRepository) GetItems(ctx context.Context, statementID int64) ([]entity.StatementItem, error) {
+	query := `
+		SELECT transaction_id, installment_number, total_installments, title, type, amount_cents, date, status
+		FROM (` + statementItems + `) i
+		WHERE i.statement_id = $1
+		ORDER BY date, transaction_id, installment_number
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, statementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []entity.StatementItem{}
+	for rows.Next() {
+		var item entity.StatementItem
+		err := rows.Scan(
+			&item.TransactionID,
+			&item.InstallmentNumber,
+			&item.TotalInstallments,
+			&item.Title,
+			&item.Type,
+			&item.AmountCents,
+			&item.Date,
+			&item.Status,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan statement item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// ClearTransaction remove a transação e suas parcelas das faturas
+func (r *StatementRepository) ClearTransaction(ctx context.Context, transactionID int64) error {
+	if _, err := conn(ctx, r.db).Exec(ctx, `UPDATE transactions SET statement_id = NULL WHERE id = $1`, transactionID); err != nil {
+		return fmt.Errorf("failed to clear transaction statement: %w", err)
+	}
+
+	if _, err := conn(ctx, r.db).Exec(ctx, `UPDATE transaction_installments SET statement_id = NULL WHERE transaction_id = $1`, transactionID); err != nil {
+		return fmt.Errorf("failed to clear installment statements: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StatementRepository) AssignTransaction(ctx context.Context, transactionID int64, statementID int64) error {
+	_, err := conn(ctx, r.db).Exec(ctx, `UPDATE transactions SET statement_id = $1 WHERE id = $2`, statementID, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to assign transaction statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StatementRepository) AssignInstallment(ctx context.Context, installmentID int64, statementID int64) error {
+	_, err := conn(ctx, r.db).Exec(ctx, `UPDATE transaction_installments SET statement_id = $1 WHERE id = $2`, statementID, installmentID)
+	if err != nil {
+		return fmt.Errorf("failed to assign installment statement: %w", err)
+	}
+
+	return nil
+}
+
+// MarkPaid registra o pagamento da fatura e a transferência usada para pagá-la, se houver
+func (r *StatementRepository) MarkPaid(ctx context.Context, id int64, paidAt time.Time, paymentTransactionID *int64) error {
+	query := `
+		UPDATE statements
+		SET paid_at = $1, payment_transaction_id = $2, updated_at = NOW()
+		WHERE id = $3
+	`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, paidAt, paymentTransactionID, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark statement as paid: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"manager/internal/entity"
+)
+
+// Colunas SQL correspondentes a cada campo de ordenação
+var transactionSortColumns = map[string]string{
+	entity.TransactionSortDueDate:   "t.due_date",
+	entity.TransactionSortAmount:    "t.amount_cents",
+	entity.TransactionSortCreatedAt: "t.created_at",
+	entity.TransactionSortTitle:     "t.title",
+}
+
+// transactionCursor identifica a última linha da página anterior (valor da ordenação + id)
+type transactionCursor struct {
+	SortBy string `json:"s"`
+	Value  string `json:"v"`
+	ID     int64  `json:"id"`
+}
+
+// queryArgs acumula os parâmetros posicionais de uma consulta montada dinamicamente
+type queryArgs struct {
+	values []any
+}
+
+func (a *queryArgs) add(value any) string {
+	a.values = append(a.values, value)
+	return fmt.Sprintf("$%d", len(a.values))
+}
+
+// buildTransactionConditions monta a cláusula WHERE (sem o cursor) para o filtro informado
+func buildTransactionConditions(filter entity.TransactionFilter, args *queryArgs) []string {
+	var conditions []string
+
+	if filter.DueFrom != nil {
+		conditions = append(conditions, "t.due_date >= "+args.add(*filter.DueFrom))
+	}
+
+	if filter.DueTo != nil {
+		conditions = append(conditions, "t.due_date <= "+args.add(*filter.DueTo))
+	}
+
+	if len(filter.Types) > 0 {
+		types := make([]string, len(filter.Types))
+		for i, t := range filter.Types {
+			types[i] = string(t)
+		}
+		conditions = append(conditions, "t.type = ANY("+args.add(types)+")")
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, s := range filter.Statuses {
+			statuses[i] = string(s)
+		}
+		conditions = append(conditions, "t.status = ANY("+args.add(statuses)+")")
+	}
+
+	if len(filter.CategoryIDs) > 0 {
+		conditions = append(conditions, "t.category_id = ANY("+args.add(filter.CategoryIDs)+")")
+	}
+
+	if len(filter.AccountIDs) > 0 {
+		// Transferências aparecem tanto na conta de origem quanto na de destino
+		accountIDs := args.add(filter.AccountIDs)
+		conditions = append(conditions, "(t.account_id = ANY("+accountIDs+") OR t.destination_account_id = ANY("+accountIDs+"))")
+	}
+
+	if filter.MinAmountCents != nil {
+		conditions = append(conditions, "t.amount_cents >= "+args.add(*filter.MinAmountCents))
+	}
+
+	if filter.MaxAmountCents != nil {
+		conditions = append(conditions, "t.amount_cents <= "+args.add(*filter.MaxAmountCents))
+	}
+
+	if filter.Search != "" {
+		pattern := args.add("%" + escapeLike(filter.Search) + "%")
+		conditions = append(conditions, "(t.title ILIKE "+pattern+" OR t.description ILIKE "+pattern+")")
+	}
+
+	return conditions
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
+
+func encodeTransactionCursor(sortBy string, t *entity.Transaction) string {
+	var value string
+	switch sortBy {
+	case entity.TransactionSortAmount:
+		value = strconv.FormatInt(t.AmountCents, 10)
+	case entity.TransactionSortCreatedAt:
+		value = t.CreatedAt.Format(time.RFC3339Nano)
+	case entity.TransactionSortTitle:
+		value = t.Title
+	default:
+		value = t.DueDate.Format("2006-01-02")
+	}
+
+	data, _ := json.Marshal(transactionCursor{SortBy: sortBy, Value: value, ID: t.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeTransactionCursor retorna o valor tipado da ordenação e o id gravados no cursor
+func decodeTransactionCursor(sortBy string, cursor string) (any, int64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: invalid cursor", entity.ErrInvalidFilter)
+	}
+
+	var c transactionCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.SortBy != sortBy {
+		return nil, 0, fmt.Errorf("%w: invalid cursor", entity.ErrInvalidFilter)
+	}
+
+	switch sortBy {
+	case entity.TransactionSortAmount:
+		value, err := strconv.ParseInt(c.Value, 10, 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: invalid cursor", entity.ErrInvalidFilter)
+		}
+		return value, c.ID, nil
+	case entity.TransactionSortCreatedAt:
+		value, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: invalid cursor", entity.ErrInvalidFilter)
+		}
+		return value, c.ID, nil
+	case entity.TransactionSortTitle:
+		return c.Value, c.ID, nil
+	default:
+		value, err := time.Parse("2006-01-02", c.Value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: invalid cursor", entity.ErrInvalidFilter)
+		}
+		return value, c.ID, nil
+	}
+}
@@ -2,11 +2,13 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"manager/internal/entity"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,12 +22,14 @@ func NewTransactionRepository(db *pgxpool.Pool) *TransactionRepository {
 
 func (r *TransactionRepository) Create(ctx context.Context, transaction *entity.Transaction) error {
 	query := `
-		INSERT INTO transactions (title, description, amount_cents, type, category_id, due_date, is_recurring, is_installment, total_installments, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO transactions (title, description, amount_cents, type, category_id, due_date, is_recurring, recurrence_id, is_installment, total_installments,
+			installment_schedule, installment_interval_days, installment_interest_rate, amortization_method, down_payment_cents, remainder_policy, status, account_id,
+			destination_account_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''), $12, $13, NULLIF($14, ''), $15, NULLIF($16, ''), $17, $18, $19)
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query,
+	err := conn(ctx, r.db).QueryRow(ctx, query,
 		transaction.Title,
 		transaction.Description,
 		transaction.AmountCents,
@@ -33,9 +37,18 @@ func (r *TransactionRepository) Create(ctx context.Context, transaction *entity.
 		transaction.CategoryID,
 		transaction.DueDate,
 		transaction.IsRecurring,
+		transaction.RecurrenceID,
 		transaction.IsInstallment,
 		transaction.TotalInstallments,
+		transaction.InstallmentSchedule,
+		transaction.InstallmentIntervalDays,
+		transaction.InterestRate,
+		transaction.AmortizationMethod,
+		transaction.DownPaymentCents,
+		transaction.RemainderPolicy,
 		transaction.Status,
+		transaction.AccountID,
+		transaction.DestinationAccountID,
 	).Scan(&transaction.ID, &transaction.CreatedAt, &transaction.UpdatedAt)
 
 	if err != nil {
@@ -45,25 +58,59 @@ func (r *TransactionRepository) Create(ctx context.Context, transaction *entity.
 	return nil
 }
 
-func (r *TransactionRepository) GetAll(ctx context.Context) ([]entity.Transaction, error) {
+func (r *TransactionRepository) GetAll(ctx context.Context, filter entity.TransactionFilter) (*entity.TransactionPage, error) {
+	sortColumn, ok := transactionSortColumns[filter.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid sort field %s", entity.ErrInvalidFilter, filter.SortBy)
+	}
+
+	direction := "DESC"
+	comparison := "<"
+	if filter.SortDirection == entity.SortAsc {
+		direction = "ASC"
+		comparison = ">"
+	}
+
+	args := &queryArgs{}
+	conditions := buildTransactionConditions(filter, args)
+
+	// Total considerando apenas os filtros, sem o cursor
+	countQuery := `SELECT COUNT(*) FROM transactions t ` + whereClause(conditions)
+
+	var total int64
+	if err := conn(ctx, r.db).QueryRow(ctx, countQuery, args.values...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	if filter.Cursor != "" {
+		value, id, err := decodeTransactionCursor(filter.SortBy, filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, t.id) %s (%s, %s)", sortColumn, comparison, args.add(value), args.add(id)))
+	}
+
 	query := `
 		SELECT 
 			t.id, t.title, t.description, t.amount_cents, t.type, 
-			t.category_id, t.due_date, t.is_recurring, t.is_installment, 
-			t.total_installments, t.status, t.created_at, t.updated_at,
+			t.category_id, t.account_id, t.destination_account_id, t.due_date, t.is_recurring, t.recurrence_id, t.is_installment, 
+			t.total_installments, COALESCE(t.installment_schedule, ''), t.installment_interval_days,
+			t.installment_interest_rate::float8, COALESCE(t.amortization_method, ''), t.down_payment_cents, COALESCE(t.remainder_policy, ''),
+			t.status, t.overdue_at, t.paid_at, t.paid_amount_cents, t.settled_amount_cents, t.created_at, t.updated_at,
 			c.id, c.name, c.description, c.color, c.icon, c.created_at, c.updated_at
 		FROM transactions t
 		LEFT JOIN categories c ON t.category_id = c.id
-		ORDER BY t.due_date DESC, t.created_at DESC
-	`
+		` + whereClause(conditions) + `
+		ORDER BY ` + sortColumn + ` ` + direction + `, t.id ` + direction + `
+		LIMIT ` + args.add(filter.Limit+1)
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := conn(ctx, r.db).Query(ctx, query, args.values...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
 	defer rows.Close()
 
-	var transactions []entity.Transaction
+	transactions := make([]entity.Transaction, 0, filter.Limit)
 	for rows.Next() {
 		var t entity.Transaction
 		var categoryID *int64
@@ -77,11 +124,24 @@ func (r *TransactionRepository) GetAll(ctx context.Context) ([]entity.Transactio
 			&t.AmountCents,
 			&t.Type,
 			&categoryID,
+			&t.AccountID,
+			&t.DestinationAccountID,
 			&t.DueDate,
 			&t.IsRecurring,
+			&t.RecurrenceID,
 			&t.IsInstallment,
 			&t.TotalInstallments,
+			&t.InstallmentSchedule,
+			&t.InstallmentIntervalDays,
+			&t.InterestRate,
+			&t.AmortizationMethod,
+			&t.DownPaymentCents,
+			&t.RemainderPolicy,
 			&t.Status,
+			&t.OverdueAt,
+			&t.PaidAt,
+			&t.PaidAmountCents,
+			&t.SettledAmountCents,
 			&t.CreatedAt,
 			&t.UpdatedAt,
 			&catID,
@@ -102,26 +162,53 @@ func (r *TransactionRepository) GetAll(ctx context.Context) ([]entity.Transactio
 			t.Category = &cat
 		}
 
-		// Buscar parcelas se for parcelada
+		transactions = append(transactions, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	rows.Close()
+
+	page := &entity.TransactionPage{Total: total}
+
+	// A linha extra indica que existe uma próxima página
+	if len(transactions) > filter.Limit {
+		transactions = transactions[:filter.Limit]
+		cursor := encodeTransactionCursor(filter.SortBy, &transactions[len(transactions)-1])
+		page.NextCursor = &cursor
+	}
+
+	// Buscar as parcelas de todas as transações parceladas da página em uma única consulta
+	var installmentTransactionIDs []int64
+	for _, t := range transactions {
 		if t.IsInstallment {
-			installments, err := r.GetInstallmentsByTransactionID(ctx, t.ID)
-			if err == nil {
-				t.Installments = installments
-			}
+			installmentTransactionIDs = append(installmentTransactionIDs, t.ID)
 		}
+	}
 
-		transactions = append(transactions, t)
+	if len(installmentTransactionIDs) > 0 {
+		installments, err := r.GetInstallmentsByTransactionIDs(ctx, installmentTransactionIDs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range transactions {
+			transactions[i].Installments = installments[transactions[i].ID]
+		}
 	}
 
-	return transactions, nil
+	page.Data = transactions
+
+	return page, nil
 }
 
 func (r *TransactionRepository) GetByID(ctx context.Context, id int64) (*entity.Transaction, error) {
 	query := `
 		SELECT 
 			t.id, t.title, t.description, t.amount_cents, t.type, 
-			t.category_id, t.due_date, t.is_recurring, t.is_installment, 
-			t.total_installments, t.status, t.created_at, t.updated_at,
+			t.category_id, t.account_id, t.destination_account_id, t.due_date, t.is_recurring, t.recurrence_id, t.is_installment, 
+			t.total_installments, COALESCE(t.installment_schedule, ''), t.installment_interval_days,
+			t.installment_interest_rate::float8, COALESCE(t.amortization_method, ''), t.down_payment_cents, COALESCE(t.remainder_policy, ''),
+			t.status, t.overdue_at, t.paid_at, t.paid_amount_cents, t.settled_amount_cents, t.created_at, t.updated_at,
 			c.id, c.name, c.description, c.color, c.icon, c.created_at, c.updated_at
 		FROM transactions t
 		LEFT JOIN categories c ON t.category_id = c.id
@@ -133,18 +220,31 @@ func (r *TransactionRepository) GetByID(ctx context.Context, id int64) (*entity.
 	var cat entity.Category
 	var catID *int64
 
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRow(ctx, query, id).Scan(
 		&t.ID,
 		&t.Title,
 		&t.Description,
 		&t.AmountCents,
 		&t.Type,
 		&categoryID,
+		&t.AccountID,
+		&t.DestinationAccountID,
 		&t.DueDate,
 		&t.IsRecurring,
+		&t.RecurrenceID,
 		&t.IsInstallment,
 		&t.TotalInstallments,
+		&t.InstallmentSchedule,
+		&t.InstallmentIntervalDays,
+		&t.InterestRate,
+		&t.AmortizationMethod,
+		&t.DownPaymentCents,
+		&t.RemainderPolicy,
 		&t.Status,
+		&t.OverdueAt,
+		&t.PaidAt,
+		&t.PaidAmountCents,
+		&t.SettledAmountCents,
 		&t.CreatedAt,
 		&t.UpdatedAt,
 		&catID,
@@ -156,6 +256,9 @@ func (r *TransactionRepository) GetByID(ctx context.Context, id int64) (*entity.
 		&cat.UpdatedAt,
 	)
 
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get transaction: %w", entity.ErrNotFound)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
@@ -169,9 +272,10 @@ func (r *TransactionRepository) GetByID(ctx context.Context, id int64) (*entity.
 	// Buscar parcelas se for parcelada
 	if t.IsInstallment {
 		installments, err := r.GetInstallmentsByTransactionID(ctx, t.ID)
-		if err == nil {
-			t.Installments = installments
+		if err != nil {
+			return nil, err
 		}
+		t.Installments = installments
 	}
 
 	return &t, nil
@@ -182,12 +286,19 @@ func (r *TransactionRepository) Update(ctx context.Context, transaction *entity.
 		UPDATE transactions
 		SET title = $1, description = $2, amount_cents = $3, type = $4, 
 		    category_id = $5, due_date = $6, is_recurring = $7, 
-		    is_installment = $8, total_installments = $9, status = $10, updated_at = NOW()
-		WHERE id = $11
+		    is_installment = $8, total_installments = $9, status = $10,
+		    paid_at = CASE WHEN $10 = 'paid' THEN COALESCE(paid_at, NOW()) END,
+		    paid_amount_cents = CASE WHEN $10 = 'paid' THEN COALESCE(paid_amount_cents, $3) END,
+		    settled_amount_cents = CASE WHEN amount_cents = $3 THEN settled_amount_cents END,
+		    installment_schedule = NULLIF($11, ''), installment_interval_days = $12,
+		    installment_interest_rate = $13, amortization_method = NULLIF($14, ''),
+		    down_payment_cents = $15, remainder_policy = NULLIF($16, ''), account_id = $17,
+		    destination_account_id = $18, updated_at = NOW()
+		WHERE id = $19
 		RETURNING updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query,
+	err := conn(ctx, r.db).QueryRow(ctx, query,
 		transaction.Title,
 		transaction.Description,
 		transaction.AmountCents,
@@ -198,6 +309,14 @@ func (r *TransactionRepository) Update(ctx context.Context, transaction *entity.
 		transaction.IsInstallment,
 		transaction.TotalInstallments,
 		transaction.Status,
+		transaction.InstallmentSchedule,
+		transaction.InstallmentIntervalDays,
+		transaction.InterestRate,
+		transaction.AmortizationMethod,
+		transaction.DownPaymentCents,
+		transaction.RemainderPolicy,
+		transaction.AccountID,
+		transaction.DestinationAccountID,
 		transaction.ID,
 	).Scan(&transaction.UpdatedAt)
 
@@ -211,7 +330,7 @@ func (r *TransactionRepository) Update(ctx context.Context, transaction *entity.
 func (r *TransactionRepository) Delete(ctx context.Context, id int64) error {
 	query := `DELETE FROM transactions WHERE id = $1`
 
-	_, err := r.db.Exec(ctx, query, id)
+	_, err := conn(ctx, r.db).Exec(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete transaction: %w", err)
 	}
@@ -219,6 +338,91 @@ func (r *TransactionRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// Pay registra o pagamento de uma transação simples
+func (r *TransactionRepository) Pay(ctx context.Context, id int64, paidAt time.Time, paidAmountCents int64) error {
+	query := `
+		UPDATE transactions
+		SET status = $1, paid_at = $2, paid_amount_cents = $3, settled_amount_cents = $3, updated_at = NOW()
+		WHERE id = $4 AND is_installment = false
+	`
+
+	tag, err := conn(ctx, r.db).Exec(ctx, query, entity.TransactionStatusPaid, paidAt, paidAmountCents, id)
+	if err != nil {
+		return fmt.Errorf("failed to pay transaction: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to pay transaction: %w", entity.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Unpay desfaz o pagamento de uma transação simples, voltando-a para pendente
+func (r *TransactionRepository) Unpay(ctx context.Context, id int64) error {
+	query := `
+		UPDATE transactions
+		SET status = $1, paid_at = NULL, paid_amount_cents = NULL, settled_amount_cents = NULL, overdue_at = NULL, updated_at = NOW()
+		WHERE id = $2 AND is_installment = false
+	`
+
+	tag, err := conn(ctx, r.db).Exec(ctx, query, entity.TransactionStatusPending, id)
+	if err != nil {
+		return fmt.Errorf("failed to unpay transaction: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to unpay transaction: %w", entity.ErrNotFound)
+	}
+
+	return nil
+}
+
+// MarkPartiallyPaid marca uma transação simples como parcialmente paga. Transações vencidas
+// continuam vencidas até serem quitadas.
+func (r *TransactionRepository) MarkPartiallyPaid(ctx context.Context, id int64) error {
+	query := `
+		UPDATE transactions
+		SET status = CASE WHEN status = 'overdue' THEN status ELSE $1 END,
+		    paid_at = NULL, paid_amount_cents = NULL, updated_at = NOW()
+		WHERE id = $2 AND is_installment = false
+	`
+
+	tag, err := conn(ctx, r.db).Exec(ctx, query, entity.TransactionStatusPartiallyPaid, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark transaction as partially paid: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to mark transaction as partially paid: %w", entity.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetPaymentDifferences retorna a soma de (valor pago - valor previsto) das transações simples
+// pagas no período [from, to), separada em receitas e despesas
+func (r *TransactionRepository) GetPaymentDifferences(ctx context.Context, from, to time.Time) (int64, int64, error) {
+
+	query := `
+		SELECT
+			COALESCE(SUM(CASE WHEN type = 'income' THEN paid_amount_cents - amount_cents ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN type = 'expense' THEN paid_amount_cents - amount_cents ELSE 0 END), 0)
+		FROM transactions
+		WHERE status = 'paid' AND is_installment = false
+		AND paid_amount_cents IS NOT NULL
+		AND paid_at >= $1 AND paid_at < $2
+	`
+
+	var income, expense int64
+	err := conn(ctx, r.db).QueryRow(ctx, query, from, to).Scan(&income, &expense)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get payment differences: %w", err)
+	}
+
+	return income, expense, nil
+}
+
 func (r *TransactionRepository) CreateInstallment(ctx context.Context, installment *entity.Installment) error {
 	query := `
 		INSERT INTO transaction_installments (transaction_id, installment_number, amount_cents, due_date, status)
@@ -226,7 +430,7 @@ func (r *TransactionRepository) CreateInstallment(ctx context.Context, installme
 		RETURNING id, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(ctx, query,
+	err := conn(ctx, r.db).QueryRow(ctx, query,
 		installment.TransactionID,
 		installment.InstallmentNumber,
 		installment.AmountCents,
@@ -243,13 +447,13 @@ func (r *TransactionRepository) CreateInstallment(ctx context.Context, installme
 
 func (r *TransactionRepository) GetInstallmentsByTransactionID(ctx context.Context, transactionID int64) ([]entity.Installment, error) {
 	query := `
-		SELECT id, transaction_id, installment_number, amount_cents, due_date, status, paid_at, created_at, updated_at
+		SELECT id, transaction_id, installment_number, amount_cents, due_date, status, paid_at, overdue_at, created_at, updated_at
 		FROM transaction_installments
 		WHERE transaction_id = $1
 		ORDER BY installment_number ASC
 	`
 
-	rows, err := r.db.Query(ctx, query, transactionID)
+	rows, err := conn(ctx, r.db).Query(ctx, query, transactionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get installments: %w", err)
 	}
@@ -266,6 +470,7 @@ func (r *TransactionRepository) GetInstallmentsByTransactionID(ctx context.Conte
 			&inst.DueDate,
 			&inst.Status,
 			&inst.PaidAt,
+			&inst.OverdueAt,
 			&inst.CreatedAt,
 			&inst.UpdatedAt,
 		)
@@ -274,108 +479,366 @@ func (r *TransactionRepository) GetInstallmentsByTransactionID(ctx context.Conte
 		}
 		installments = append(installments, inst)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get installments: %w", err)
+	}
 
 	return installments, nil
 }
 
-func (r *TransactionRepository) PayInstallment(ctx context.Context, transactionID int64, installmentNumber int) error {
+// UpdateInstallment regrava valor e vencimento de uma parcela não paga, voltando-a para pendente
+func (r *TransactionRepository) UpdateInstallment(ctx context.Context, installment *entity.Installment) error {
 	query := `
 		UPDATE transaction_installments
-		SET status = $1, paid_at = NOW(), updated_at = NOW()
-		WHERE transaction_id = $2 AND installment_number = $3
+		SET amount_cents = $1, due_date = $2, status = $3, overdue_at = NULL, updated_at = NOW()
+		WHERE transaction_id = $4 AND installment_number = $5
+		RETURNING id, updated_at
+	`
+
+	err := conn(ctx, r.db).QueryRow(ctx, query,
+		installment.AmountCents,
+		installment.DueDate,
+		installment.Status,
+		installment.TransactionID,
+		installment.InstallmentNumber,
+	).Scan(&installment.ID, &installment.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to update installment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TransactionRepository) DeleteInstallment(ctx context.Context, transactionID int64, installmentNumber int) error {
+	query := `DELETE FROM transaction_installments WHERE transaction_id = $1 AND installment_number = $2`
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, transactionID, installmentNumber)
+	if err != nil {
+		return fmt.Errorf("failed to delete installment: %w", err)
+	}
+
+	return nil
+}
+
+// GetInstallmentsByTransactionIDs busca as parcelas de várias transações de uma vez, agrupadas por transação
+func (r *TransactionRepository) GetInstallmentsByTransactionIDs(ctx context.Context, transactionIDs []int64) (map[int64][]entity.Installment, error) {
+	query := `
+		SELECT id, transaction_id, installment_number, amount_cents, due_date, status, paid_at, overdue_at, created_at, updated_at
+		FROM transaction_installments
+		WHERE transaction_id = ANY($1)
+		ORDER BY transaction_id ASC, installment_number ASC
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, transactionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installments: %w", err)
+	}
+	defer rows.Close()
+
+	installments := make(map[int64][]entity.Installment, len(transactionIDs))
+	for rows.Next() {
+		var inst entity.Installment
+		err := rows.Scan(
+			&inst.ID,
+			&inst.TransactionID,
+			&inst.InstallmentNumber,
+			&inst.AmountCents,
+			&inst.DueDate,
+			&inst.Status,
+			&inst.PaidAt,
+			&inst.OverdueAt,
+			&inst.CreatedAt,
+			&inst.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan installment: %w", err)
+		}
+		installments[inst.TransactionID] = append(installments[inst.TransactionID], inst)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get installments: %w", err)
+	}
+
+	return installments, nil
+}
+
+func (r *TransactionRepository) GetInstallment(ctx context.Context, transactionID int64, installmentNumber int) (*entity.Installment, error) {
+	query := `
+		SELECT id, transaction_id, installment_number, amount_cents, due_date, status, paid_at, overdue_at, created_at, updated_at
+		FROM transaction_installments
+		WHERE transaction_id = $1 AND installment_number = $2
+	`
+
+	var inst entity.Installment
+	err := conn(ctx, r.db).QueryRow(ctx, query, transactionID, installmentNumber).Scan(
+		&inst.ID,
+		&inst.TransactionID,
+		&inst.InstallmentNumber,
+		&inst.AmountCents,
+		&inst.DueDate,
+		&inst.Status,
+		&inst.PaidAt,
+		&inst.OverdueAt,
+		&inst.CreatedAt,
+		&inst.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("installment %d: %w", installmentNumber, entity.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installment: %w", err)
+	}
+
+	return &inst, nil
+}
+
+func (r *TransactionRepository) PayInstallment(ctx context.Context, transactionID int64, installmentNumber int, paidAt time.Time) error {
+	query := `
+		UPDATE transaction_installments
+		SET status = $1, paid_at = $2, updated_at = NOW()
+		WHERE transaction_id = $3 AND installment_number = $4
 	`
 
-	_, err := r.db.Exec(ctx, query, entity.InstallmentStatusPaid, transactionID, installmentNumber)
+	tag, err := conn(ctx, r.db).Exec(ctx, query, entity.InstallmentStatusPaid, paidAt, transactionID, installmentNumber)
 	if err != nil {
 		return fmt.Errorf("failed to pay installment: %w", err)
 	}
 
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("installment %d: %w", installmentNumber, entity.ErrNotFound)
+	}
+
+	return nil
+}
+
+// UnpayInstallment desfaz o pagamento, voltando a parcela para pendente
+func (r *TransactionRepository) UnpayInstallment(ctx context.Context, transactionID int64, installmentNumber int) error {
+	query := `
+		UPDATE transaction_installments
+		SET status = $1, paid_at = NULL, overdue_at = NULL, updated_at = NOW()
+		WHERE transaction_id = $2 AND installment_number = $3
+	`
+
+	tag, err := conn(ctx, r.db).Exec(ctx, query, entity.InstallmentStatusPending, transactionID, installmentNumber)
+	if err != nil {
+		return fmt.Errorf("failed to unpay installment: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("installment %d: %w", installmentNumber, entity.ErrNotFound)
+	}
+
 	return nil
 }
 
-func (r *TransactionRepository) GetMonthlySummary(ctx context.Context, year int, month int) (int64, int64, error) {
-	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	endDate := startDate.AddDate(0, 1, 0)
+// CancelUnpaidInstallments cancela todas as parcelas pendentes ou vencidas da transação
+func (r *TransactionRepository) CancelUnpaidInstallments(ctx context.Context, transactionID int64) (int64, error) {
+	query := `
+		UPDATE transaction_installments
+		SET status = $1, updated_at = NOW()
+		WHERE transaction_id = $2 AND status IN ('pending', 'overdue')
+	`
+
+	tag, err := conn(ctx, r.db).Exec(ctx, query, entity.InstallmentStatusCancelled, transactionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel installments: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
 
-	// Para transações não parceladas, usar o valor total
-	// Para transações parceladas, somar apenas as parcelas com vencimento no mês
+// RefreshInstallmentStatus recalcula o status das compras parceladas a partir das parcelas:
+// paga quando todas as não canceladas estão pagas, vencida quando alguma está vencida e
+// parcialmente paga quando parte está paga ou há pagamentos parciais. Sem ids, recalcula todas. Transações canceladas
+// manualmente não são alteradas.
+func (r *TransactionRepository) RefreshInstallmentStatus(ctx context.Context, transactionIDs ...int64) error {
 	query := `
-		SELECT 
-			COALESCE(SUM(
-				CASE 
-					WHEN t.is_installment = false AND t.type = 'income' THEN t.amount_cents
-					WHEN t.is_installment = true AND t.type = 'income' THEN COALESCE(SUM(ti.amount_cents), 0)
-					ELSE 0
-				END
-			), 0) as income,
-			COALESCE(SUM(
-				CASE 
-					WHEN t.is_installment = false AND t.type = 'expense' THEN t.amount_cents
-					WHEN t.is_installment = true AND t.type = 'expense' THEN COALESCE(SUM(ti.amount_cents), 0)
-					ELSE 0
-				END
-			), 0) as expense
+		UPDATE transactions t
+		SET status = s.status, updated_at = NOW()
+		FROM (
+			SELECT transaction_id,
+				CASE
+					WHEN COUNT(*) FILTER (WHERE status <> 'cancelled') = 0 THEN 'cancelled'
+					WHEN COUNT(*) FILTER (WHERE status = 'overdue') > 0 THEN 'overdue'
+					WHEN COUNT(*) FILTER (WHERE status = 'paid') = COUNT(*) FILTER (WHERE status <> 'cancelled') THEN 'paid'
+					WHEN COUNT(*) FILTER (WHERE status = 'paid') > 0
+						OR transaction_id IN (SELECT transaction_id FROM payments) THEN 'partially_paid'
+					ELSE 'pending'
+				END AS status
+			FROM transaction_installments
+			WHERE cardinality($1::bigint[]) = 0 OR transaction_id = ANY($1)
+			GROUP BY transaction_id
+		) s
+		WHERE t.id = s.transaction_id
+		AND t.is_installment = true
+		AND t.status <> 'cancelled'
+		AND t.status <> s.status
+	`
+
+	if transactionIDs == nil {
+		transactionIDs = []int64{}
+	}
+
+	_, err := conn(ctx, r.db).Exec(ctx, query, transactionIDs)
+	if err != nil {
+		return fmt.Errorf("failed to refresh installment status: %w", err)
+	}
+
+	return nil
+}
+
+// MarkOverdue marca como vencidas as transações simples pendentes ou parcialmente pagas e as
+// parcelas pendentes com vencimento anterior a today
+func (r *TransactionRepository) MarkOverdue(ctx context.Context, today time.Time) (int64, int64, error) {
+	transactionsQuery := `
+		UPDATE transactions
+		SET status = $1, overdue_at = NOW(), updated_at = NOW()
+		WHERE status IN ($2, $4) AND is_installment = false AND type != 'transfer' AND due_date < $3
+	`
+
+	tag, err := conn(ctx, r.db).Exec(ctx, transactionsQuery, entity.TransactionStatusOverdue, entity.TransactionStatusPending, today, entity.TransactionStatusPartiallyPaid)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to mark overdue transactions: %w", err)
+	}
+	transactions := tag.RowsAffected()
+
+	installmentsQuery := `
+		UPDATE transaction_installments ti
+		SET status = $1, overdue_at = NOW(), updated_at = NOW()
 		FROM transactions t
-		LEFT JOIN transaction_installments ti ON t.id = ti.transaction_id 
-			AND ti.due_date >= $1 AND ti.due_date < $2
-		WHERE (t.is_installment = false AND t.due_date >= $1 AND t.due_date < $2)
-		   OR (t.is_installment = true)
+		WHERE ti.transaction_id = t.id
+		AND ti.status = $2 AND ti.due_date < $3
 		AND t.status != 'cancelled'
-		GROUP BY t.id
 	`
 
-	// Query simplificada - somar transações não parceladas e parcelas do mês
-	query = `
-		SELECT 
-			COALESCE(SUM(CASE WHEN type = 'income' AND is_installment = false THEN amount_cents ELSE 0 END), 0) +
-			COALESCE(SUM(CASE WHEN type = 'income' AND is_installment = true THEN 0 ELSE 0 END), 0) +
-			COALESCE((SELECT SUM(amount_cents) FROM transaction_installments ti 
-				INNER JOIN transactions t2 ON ti.transaction_id = t2.id 
-				WHERE ti.due_date >= $1 AND ti.due_date < $2 AND t2.type = 'income'), 0) as income,
-			COALESCE(SUM(CASE WHEN type = 'expense' AND is_installment = false THEN amount_cents ELSE 0 END), 0) +
-			COALESCE((SELECT SUM(amount_cents) FROM transaction_installments ti 
-				INNER JOIN transactions t2 ON ti.transaction_id = t2.id 
-				WHERE ti.due_date >= $1 AND ti.due_date < $2 AND t2.type = 'expense'), 0) as expense
-		FROM transactions
-		WHERE (is_installment = false AND due_date >= $1 AND due_date < $2)
-		AND status != 'cancelled'
+	tag, err = conn(ctx, r.db).Exec(ctx, installmentsQuery, entity.InstallmentStatusOverdue, entity.InstallmentStatusPending, today)
+	if err != nil {
+		return transactions, 0, fmt.Errorf("failed to mark overdue installments: %w", err)
+	}
+
+	return transactions, tag.RowsAffected(), nil
+}
+
+// As agregações abaixo são feitas sobre a view ledger_entries, em que cada transação simples e
+// cada parcela é um lançamento com data, valor efetivo (amount_cents) e valor quitado (paid_cents).
+// Transações e parcelas canceladas não fazem parte da view.
+
+// GetOverdueSummary retorna a quantidade de lançamentos vencidos e o total ainda em aberto
+func (r *TransactionRepository) GetOverdueSummary(ctx context.Context) (int64, int64, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(amount_cents - paid_cents), 0)
+		FROM ledger_entries
+		WHERE status = 'overdue'
+	`
+
+	var count, total int64
+	err := conn(ctx, r.db).QueryRow(ctx, query).Scan(&count, &total)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get overdue summary: %w", err)
+	}
+
+	return count, total, nil
+}
+
+// GetBills lista as transações simples e parcelas com saldo em aberto, com o valor ainda não
+// pago, em ordem de vencimento. Transferências não entram.
+func (r *TransactionRepository) GetBills(ctx context.Context, filter entity.BillFilter) ([]entity.Bill, error) {
+	args := &queryArgs{}
+	conditions := []string{"e.type != 'transfer'", "e.amount_cents > e.paid_cents"}
+
+	statuses := filter.Statuses
+	if len(statuses) == 0 {
+		statuses = entity.BillOpenStatuses
+	}
+	conditions = append(conditions, "e.status = ANY("+args.add(statuses)+")")
+
+	if filter.DueFrom != nil {
+		conditions = append(conditions, "e.date >= "+args.add(*filter.DueFrom))
+	}
+
+	if filter.DueTo != nil {
+		conditions = append(conditions, "e.date <= "+args.add(*filter.DueTo))
+	}
+
+	if filter.Type != "" {
+		conditions = append(conditions, "e.type = "+args.add(string(filter.Type)))
+	}
+
+	query := `
+		SELECT e.transaction_id, e.installment_number,
+			CASE WHEN e.installment_id IS NOT NULL THEN t.total_installments END,
+			t.title, e.type, e.amount_cents - e.paid_cents, e.date, e.status, e.category_id, e.account_id
+		FROM ledger_entries e
+		INNER JOIN transactions t ON e.transaction_id = t.id
+		` + whereClause(conditions) + `
+		ORDER BY e.date, e.transaction_id, e.installment_number
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, args.values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bills: %w", err)
+	}
+	defer rows.Close()
+
+	bills := []entity.Bill{}
+	for rows.Next() {
+		var bill entity.Bill
+		err := rows.Scan(
+			&bill.TransactionID,
+			&bill.InstallmentNumber,
+			&bill.TotalInstallments,
+			&bill.Title,
+			&bill.Type,
+			&bill.AmountCents,
+			&bill.DueDate,
+			&bill.Status,
+			&bill.CategoryID,
+			&bill.AccountID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bill: %w", err)
+		}
+		bills = append(bills, bill)
+	}
+
+	return bills, rows.Err()
+}
+
+// GetPeriodSummary retorna as receitas e despesas dos lançamentos com data no período [from, to).
+// Compras parceladas entram pelas parcelas que vencem no período.
+func (r *TransactionRepository) GetPeriodSummary(ctx context.Context, from, to time.Time) (int64, int64, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(amount_cents) FILTER (WHERE type = 'income'), 0) AS income,
+			COALESCE(SUM(amount_cents) FILTER (WHERE type = 'expense'), 0) AS expense
+		FROM ledger_entries
+		WHERE date >= $1 AND date < $2
 	`
 
 	var income, expense int64
-	err := r.db.QueryRow(ctx, query, startDate, endDate).Scan(&income, &expense)
+	err := conn(ctx, r.db).QueryRow(ctx, query, from, to).Scan(&income, &expense)
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to get monthly summary: %w", err)
+		return 0, 0, fmt.Errorf("failed to get period summary: %w", err)
 	}
 
 	return income, expense, nil
 }
 
+// GetTotalBalance retorna os saldos iniciais das contas mais o que já foi quitado de cada
+// lançamento de receita ou despesa. Lançamentos em aberto não entram; transferências não alteram
+// o saldo total.
 func (r *TransactionRepository) GetTotalBalance(ctx context.Context) (int64, error) {
-	// Para transações não parceladas, usar o valor total
-	// Para transações parceladas, somar apenas as parcelas pagas
 	query := `
-		SELECT 
-			COALESCE(SUM(
-				CASE 
-					WHEN t.is_installment = false THEN 
-						CASE WHEN t.type = 'income' THEN t.amount_cents ELSE -t.amount_cents END
-					ELSE 0
-				END
-			), 0) +
-			COALESCE(SUM(
-				CASE 
-					WHEN t.is_installment = true THEN 
-						CASE WHEN t.type = 'income' THEN ti.amount_cents ELSE -ti.amount_cents END
-					ELSE 0
-				END
-			), 0) as balance
-		FROM transactions t
-		LEFT JOIN transaction_installments ti ON t.id = ti.transaction_id AND ti.status = 'paid'
-		WHERE t.status != 'cancelled'
+		SELECT
+			(SELECT COALESCE(SUM(opening_balance_cents), 0) FROM accounts) +
+			COALESCE(SUM(CASE WHEN type = 'income' THEN paid_cents ELSE -paid_cents END), 0) AS balance
+		FROM ledger_entries
+		WHERE type != 'transfer'
 	`
 
 	var balance int64
-	err := r.db.QueryRow(ctx, query).Scan(&balance)
+	err := conn(ctx, r.db).QueryRow(ctx, query).Scan(&balance)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get total balance: %w", err)
 	}
@@ -383,21 +846,18 @@ func (r *TransactionRepository) GetTotalBalance(ctx context.Context) (int64, err
 	return balance, nil
 }
 
-func (r *TransactionRepository) GetCategoryExpenses(ctx context.Context, year int, month int) (map[int64]int64, error) {
-	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	endDate := startDate.AddDate(0, 1, 0)
-
+// GetCategoryExpenses retorna as despesas por categoria dos lançamentos com data no período [from, to)
+func (r *TransactionRepository) GetCategoryExpenses(ctx context.Context, from, to time.Time) (map[int64]int64, error) {
 	query := `
 		SELECT category_id, COALESCE(SUM(amount_cents), 0) as total
-		FROM transactions
-		WHERE type = 'expense' 
-		AND due_date >= $1 AND due_date < $2
-		AND status != 'cancelled'
+		FROM ledger_entries
+		WHERE type = 'expense'
+		AND date >= $1 AND date < $2
 		AND category_id IS NOT NULL
 		GROUP BY category_id
 	`
 
-	rows, err := r.db.Query(ctx, query, startDate, endDate)
+	rows, err := conn(ctx, r.db).Query(ctx, query, from, to)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get category expenses: %w", err)
 	}
@@ -413,6 +873,196 @@ func (r *TransactionRepository) GetCategoryExpenses(ctx context.Context, year in
 		expenses[categoryID] = total
 	}
 
-	return expenses, nil
+	return expenses, rows.Err()
+}
+
+// Colunas de ledger_entries usadas em cada agrupamento da série temporal
+var timeSeriesGroupColumns = map[string]string{
+	entity.TimeSeriesGroupByCategory: "e.category_id",
+	entity.TimeSeriesGroupByAccount:  "e.account_id",
+}
+
+// GetTimeSeries retorna, em uma única consulta, as receitas e despesas de cada intervalo
+// (day, week ou month) do período [from, to), agrupadas pelo vencimento, e o saldo acumulado ao
+// fim de cada um: saldos iniciais das contas mais o que foi quitado até o fim do intervalo, como
+// em GetTotalBalance. Os valores quitados entram na data de cada pagamento (ou da quitação, se não
+// houver pagamento registrado), e não no vencimento. Com groupBy, também retorna a série de cada
+// categoria ou conta. Transferências não entram.
+func (r *TransactionRepository) GetTimeSeries(ctx context.Context, from, to time.Time, granularity string, groupBy string) ([]entity.TimeSeriesPoint, []entity.TimeSeriesGroup, error) {
+	groupColumn := "NULL::BIGINT"
+	if groupBy != "" {
+		column, ok := timeSeriesGroupColumns[groupBy]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: invalid group_by %s", entity.ErrInvalidFilter, groupBy)
+		}
+		groupColumn = column
+	}
+
+	query := `
+		WITH entries AS (
+			SELECT date_trunc($1, e.date::timestamp)::date AS bucket, ` + groupColumn + ` AS group_id, e.type, e.amount_cents
+			FROM ledger_entries e
+			WHERE e.type != 'transfer' AND e.date >= $2 AND e.date < $3
+		),
+		buckets AS (
+			SELECT generate_series(date_trunc($1, $2::timestamp), date_trunc($1, $3::timestamp - INTERVAL '1 day'), ('1 ' || $1)::interval)::date AS bucket
+		),
+		settlements AS (
+			SELECT p.paid_at::date AS settled_on, CASE WHEN e.type = 'income' THEN p.amount_cents ELSE -p.amount_cents END AS cents
+			FROM ledger_entries e
+			INNER JOIN payments p ON p.transaction_id = e.transaction_id AND p.installment_id IS NOT DISTINCT FROM e.installment_id
+			WHERE e.type != 'transfer'
+			UNION ALL
+			-- Quitado além dos pagamentos registrados (ex: marcado como pago na edição)
+			SELECT COALESCE(e.paid_at::date, e.date), CASE WHEN e.type = 'income' THEN 1 ELSE -1 END * (e.paid_cents -
+				COALESCE((SELECT SUM(p.amount_cents) FROM payments p WHERE p.transaction_id = e.transaction_id AND p.installment_id IS NOT DISTINCT FROM e.installment_id), 0))
+			FROM ledger_entries e
+			WHERE e.type != 'transfer'
+		),
+		opening AS (
+			SELECT (SELECT COALESCE(SUM(opening_balance_cents), 0) FROM accounts) + COALESCE(SUM(cents), 0) AS cents
+			FROM settlements
+			WHERE settled_on < $2
+		),
+		settled AS (
+			SELECT date_trunc($1, settled_on::timestamp)::date AS bucket, SUM(cents) AS cents
+			FROM settlements
+			WHERE settled_on >= $2 AND settled_on < $3
+			GROUP BY 1
+		),
+		totals AS (
+			SELECT b.bucket,
+				COALESCE(SUM(e.amount_cents) FILTER (WHERE e.type = 'income'), 0) AS income,
+				COALESCE(SUM(e.amount_cents) FILTER (WHERE e.type = 'expense'), 0) AS expense
+			FROM buckets b
+			LEFT JOIN entries e ON e.bucket = b.bucket
+			GROUP BY b.bucket
+		),
+		groups AS (
+			SELECT bucket, group_id,
+				COALESCE(SUM(amount_cents) FILTER (WHERE type = 'income'), 0) AS income,
+				COALESCE(SUM(amount_cents) FILTER (WHERE type = 'expense'), 0) AS expense
+			FROM entries
+			WHERE $4
+			GROUP BY bucket, group_id
+		)
+		SELECT false AS grouped, NULL::BIGINT, t.bucket, t.income, t.expense,
+			(SELECT cents FROM opening) + SUM(COALESCE(s.cents, 0)) OVER (ORDER BY t.bucket)
+		FROM totals t
+		LEFT JOIN settled s ON s.bucket = t.bucket
+		UNION ALL
+		SELECT true, group_id, bucket, income, expense, 0
+		FROM groups
+		ORDER BY 1, 2 NULLS FIRST, 3
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, granularity, from, to, groupBy != "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get time series: %w", err)
+	}
+	defer rows.Close()
+
+	points := []entity.TimeSeriesPoint{}
+	groups := []entity.TimeSeriesGroup{}
+	// Pontos de cada grupo por intervalo; intervalos sem lançamentos são preenchidos abaixo
+	groupPoints := make(map[time.Time]entity.TimeSeriesPoint)
+	for rows.Next() {
+		var grouped bool
+		var groupID *int64
+		var point entity.TimeSeriesPoint
+		if err := rows.Scan(&grouped, &groupID, &point.Start, &point.Income, &point.Expense, &point.CumulativeCents); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan time series: %w", err)
+		}
+		point.Net = point.Income - point.Expense
+
+		if !grouped {
+			points = append(points, point)
+			continue
+		}
+
+		last := len(groups) - 1
+		if last < 0 || !sameGroup(groups[last].ID, groupID) {
+			if last >= 0 {
+				groups[last].Points = fillTimeSeries(points, groupPoints)
+			}
+			groups = append(groups, entity.TimeSeriesGroup{ID: groupID})
+			groupPoints = make(map[time.Time]entity.TimeSeriesPoint)
+		}
+		groupPoints[point.Start] = point
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to get time series: %w", err)
+	}
+
+	if len(groups) > 0 {
+		groups[len(groups)-1].Points = fillTimeSeries(points, groupPoints)
+	}
+
+	return points, groups, nil
 }
 
+// fillTimeSeries monta a série de um grupo com todos os intervalos da série total, com zeros nos
+// intervalos sem lançamentos, e calcula o resultado acumulado
+func fillTimeSeries(buckets []entity.TimeSeriesPoint, byStart map[time.Time]entity.TimeSeriesPoint) []entity.TimeSeriesPoint {
+	points := make([]entity.TimeSeriesPoint, len(buckets))
+	var cumulative int64
+	for i, bucket := range buckets {
+		point, ok := byStart[bucket.Start]
+		if !ok {
+			point = entity.TimeSeriesPoint{Start: bucket.Start}
+		}
+		cumulative += point.Net
+		point.CumulativeCents = cumulative
+		points[i] = point
+	}
+	return points
+}
+
+func sameGroup(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// GetCategoryBreakdown retorna os totais e a quantidade de transações por categoria dos lançamentos
+// com data no período [from, to), separados em despesas e receitas, do maior para o menor total.
+// Lançamentos sem categoria formam um grupo com category_id nulo.
+func (r *TransactionRepository) GetCategoryBreakdown(ctx context.Context, from, to time.Time) ([]entity.CategoryBreakdownItem, []entity.CategoryBreakdownItem, error) {
+	query := `
+		SELECT e.type, e.category_id,
+			COALESCE(c.name, $3), COALESCE(c.color, $4), COALESCE(c.icon, $5),
+			SUM(e.amount_cents), COUNT(DISTINCT e.transaction_id)
+		FROM ledger_entries e
+		LEFT JOIN categories c ON e.category_id = c.id
+		WHERE e.type IN ('income', 'expense')
+		AND e.date >= $1 AND e.date < $2
+		GROUP BY e.type, e.category_id, c.name, c.color, c.icon
+		ORDER BY e.type, 6 DESC, e.category_id NULLS LAST
+	`
+
+	rows, err := conn(ctx, r.db).Query(ctx, query, from, to, entity.UncategorizedName, entity.UncategorizedColor, entity.UncategorizedIcon)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get category breakdown: %w", err)
+	}
+	defer rows.Close()
+
+	expense := []entity.CategoryBreakdownItem{}
+	income := []entity.CategoryBreakdownItem{}
+	for rows.Next() {
+		var transactionType entity.TransactionType
+		var item entity.CategoryBreakdownItem
+		err := rows.Scan(&transactionType, &item.CategoryID, &item.Name, &item.Color, &item.Icon, &item.TotalCents, &item.TransactionCount)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan category breakdown: %w", err)
+		}
+
+		if transactionType == entity.TransactionTypeIncome {
+			income = append(income, item)
+		} else {
+			expense = append(expense, item)
+		}
+	}
+
+	return expense, income, rows.Err()
+}
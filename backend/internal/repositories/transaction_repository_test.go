@@ -0,0 +1,272 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"manager/internal/entity"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BenchmarkGetInstallments compara a carga das parcelas de uma página da listagem em uma única
+// consulta com a carga transação a transação
+func BenchmarkGetInstallments(b *testing.B) {
+	db := testDB(b)
+	ctx := rollbackContext(b, db)
+	repo := NewTransactionRepository(db)
+
+	const transactions, installmentsPerTransaction = 50, 12
+	ids := make([]int64, 0, transactions)
+	for range transactions {
+		installments := make([]entity.Installment, installmentsPerTransaction)
+		for i := range installments {
+			installments[i].AmountCents = 1000
+		}
+		transaction := createTestTransaction(b, ctx, repo, entity.Transaction{
+			AmountCents: 1000 * installmentsPerTransaction,
+			Type:        entity.TransactionTypeExpense,
+			DueDate:     testDate(2025, 1, 10),
+		}, installments...)
+		ids = append(ids, transaction.ID)
+	}
+
+	b.Run("batched", func(b *testing.B) {
+		for b.Loop() {
+			byTransaction, err := repo.GetInstallmentsByTransactionIDs(ctx, ids)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(byTransaction) != transactions {
+				b.Fatalf("got installments for %d transactions, want %d", len(byTransaction), transactions)
+			}
+		}
+	})
+
+	b.Run("per_row", func(b *testing.B) {
+		for b.Loop() {
+			for _, id := range ids {
+				if _, err := repo.GetInstallmentsByTransactionID(ctx, id); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
+
+// seedLedger grava, em março a maio de 2091 (longe dos dados de outros testes), uma mistura de
+// transações simples e parceladas, pagas, parciais, canceladas e uma transferência.
+// Retorna as categorias de alimentação e casa.
+func seedLedger(t *testing.T, ctx context.Context, db *pgxpool.Pool) (int64, int64, int64) {
+	t.Helper()
+
+	repo := NewTransactionRepository(db)
+	payments := NewPaymentRepository(db)
+	categories := NewCategoryRepository(db)
+	accounts := NewAccountRepository(db)
+
+	food := &entity.Category{Name: "Alimentação (teste)", Color: "#000000", Icon: "tag"}
+	home := &entity.Category{Name: "Casa (teste)", Color: "#000000", Icon: "tag"}
+	for _, category := range []*entity.Category{food, home} {
+		if err := categories.Create(ctx, category); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checking := &entity.Account{Name: "Conta (teste)", Type: entity.AccountTypeChecking, OpeningBalanceCents: 50000, Currency: "BRL"}
+	savings := &entity.Account{Name: "Poupança (teste)", Type: entity.AccountTypeSavings, Currency: "BRL"}
+	for _, account := range []*entity.Account{checking, savings} {
+		if err := accounts.Create(ctx, account); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pay := func(transactionID int64, installmentID *int64, cents int64) {
+		t.Helper()
+		payment := &entity.Payment{TransactionID: transactionID, InstallmentID: installmentID, AmountCents: cents, PaidAt: testDate(2091, 3, 20)}
+		if err := payments.Create(ctx, payment); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Receita paga com valor diferente do previsto: conta o valor pago
+	salary := createTestTransaction(t, ctx, repo, entity.Transaction{AmountCents: 300000, Type: entity.TransactionTypeIncome, DueDate: testDate(2091, 3, 5), AccountID: &checking.ID})
+	if err := repo.Pay(ctx, salary.ID, testDate(2091, 3, 5), 310000); err != nil {
+		t.Fatal(err)
+	}
+
+	// Despesa pendente
+	createTestTransaction(t, ctx, repo, entity.Transaction{AmountCents: 20000, Type: entity.TransactionTypeExpense, CategoryID: &food.ID, DueDate: testDate(2091, 3, 10)})
+
+	// Despesa parcialmente paga
+	partial := createTestTransaction(t, ctx, repo, entity.Transaction{AmountCents: 10000, Type: entity.TransactionTypeExpense, CategoryID: &food.ID, DueDate: testDate(2091, 3, 12), Status: entity.TransactionStatusPartiallyPaid})
+	pay(partial.ID, nil, 4000)
+
+	// Despesa cancelada: não entra
+	createTestTransaction(t, ctx, repo, entity.Transaction{AmountCents: 50000, Type: entity.TransactionTypeExpense, CategoryID: &home.ID, DueDate: testDate(2091, 3, 15), Status: entity.TransactionStatusCancelled})
+
+	// Parcelada em 3x: a 1ª paga, a 2ª com pagamento parcial e a 3ª cancelada
+	furniture := createTestTransaction(t, ctx, repo, entity.Transaction{AmountCents: 30000, Type: entity.TransactionTypeExpense, CategoryID: &home.ID, DueDate: testDate(2091, 3, 25)},
+		entity.Installment{AmountCents: 10000, Status: entity.InstallmentStatusPaid},
+		entity.Installment{AmountCents: 10000},
+		entity.Installment{AmountCents: 10000, Status: entity.InstallmentStatusCancelled},
+	)
+	pay(furniture.ID, &furniture.Installments[1].ID, 2500)
+
+	// Parcelada cancelada: nenhuma parcela entra
+	createTestTransaction(t, ctx, repo, entity.Transaction{AmountCents: 15000, Type: entity.TransactionTypeExpense, CategoryID: &food.ID, DueDate: testDate(2091, 3, 1), Status: entity.TransactionStatusCancelled},
+		entity.Installment{AmountCents: 7500},
+		entity.Installment{AmountCents: 7500},
+	)
+
+	// Transferência: não é receita nem despesa e não altera o saldo total
+	createTestTransaction(t, ctx, repo, entity.Transaction{AmountCents: 7000, Type: entity.TransactionTypeTransfer, DueDate: testDate(2091, 3, 8), AccountID: &checking.ID, DestinationAccountID: &savings.ID})
+
+	return food.ID, home.ID, checking.OpeningBalanceCents
+}
+
+func TestLedgerAggregations(t *testing.T) {
+	db := testDB(t)
+	ctx := rollbackContext(t, db)
+	repo := NewTransactionRepository(db)
+
+	balanceBefore, err := repo.GetTotalBalance(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	food, home, opening := seedLedger(t, ctx, db)
+
+	tests := []struct {
+		name         string
+		from, to     time.Time
+		wantIncome   int64
+		wantExpense  int64
+		wantCategory map[int64]int64
+	}{
+		{
+			name:         "march: singles and the first installment",
+			from:         testDate(2091, 3, 1),
+			to:           testDate(2091, 4, 1),
+			wantIncome:   310000,
+			wantExpense:  20000 + 10000 + 10000,
+			wantCategory: map[int64]int64{food: 30000, home: 10000},
+		},
+		{
+			name:         "april: the partially paid installment counts in full",
+			from:         testDate(2091, 4, 1),
+			to:           testDate(2091, 5, 1),
+			wantExpense:  10000,
+			wantCategory: map[int64]int64{home: 10000},
+		},
+		{
+			name:         "may: the cancelled installment does not count",
+			from:         testDate(2091, 5, 1),
+			to:           testDate(2091, 6, 1),
+			wantCategory: map[int64]int64{},
+		},
+		{
+			name:         "quarter",
+			from:         testDate(2091, 3, 1),
+			to:           testDate(2091, 6, 1),
+			wantIncome:   310000,
+			wantExpense:  50000,
+			wantCategory: map[int64]int64{food: 30000, home: 20000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			income, expense, err := repo.GetPeriodSummary(ctx, tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if income != tt.wantIncome || expense != tt.wantExpense {
+				t.Errorf("summary = %d income, %d expense; want %d, %d", income, expense, tt.wantIncome, tt.wantExpense)
+			}
+
+			expenses, err := repo.GetCategoryExpenses(ctx, tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, id := range []int64{food, home} {
+				if expenses[id] != tt.wantCategory[id] {
+					t.Errorf("category %d expenses = %d, want %d", id, expenses[id], tt.wantCategory[id])
+				}
+			}
+		})
+	}
+
+	t.Run("total balance counts only settled amounts", func(t *testing.T) {
+		balance, err := repo.GetTotalBalance(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Saldo inicial + receita paga - pagamento parcial - parcela paga - pagamento da 2ª parcela
+		want := opening + 310000 - 4000 - 10000 - 2500
+		if got := balance - balanceBefore; got != want {
+			t.Errorf("total balance changed by %d, want %d", got, want)
+		}
+
+		// O saldo acumulado da série usa a mesma definição: no último intervalo, com todos os
+		// lançamentos de teste para trás, é igual ao saldo total
+		points, _, err := repo.GetTimeSeries(ctx, testDate(2091, 3, 1), testDate(2091, 6, 1), entity.TimeSeriesMonth, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if last := points[len(points)-1]; last.CumulativeCents != balance {
+			t.Errorf("time series cumulative balance = %d, total balance = %d", last.CumulativeCents, balance)
+		}
+		if march := points[0]; march.Income != 310000 || march.Expense != 40000 {
+			t.Errorf("march point = %+v, want 310000 income and 40000 expense", march)
+		}
+
+		// O pagamento parcial da 2ª parcela (vencimento em abril) foi feito em março: o saldo de
+		// março já o desconta e não muda depois
+		for _, point := range points[1:] {
+			if point.CumulativeCents != points[0].CumulativeCents {
+				t.Errorf("cumulative balance on %s = %d, want %d as in march", point.Start.Format(time.DateOnly), point.CumulativeCents, points[0].CumulativeCents)
+			}
+		}
+	})
+}
+
+func TestFillTimeSeries(t *testing.T) {
+	buckets := []entity.TimeSeriesPoint{{Start: testDate(2025, 1, 1)}, {Start: testDate(2025, 2, 1)}, {Start: testDate(2025, 3, 1)}}
+
+	tests := []struct {
+		name    string
+		byStart map[time.Time]entity.TimeSeriesPoint
+		want    []entity.TimeSeriesPoint
+	}{
+		{
+			name: "gaps are zero and the result accumulates",
+			byStart: map[time.Time]entity.TimeSeriesPoint{
+				testDate(2025, 1, 1): {Start: testDate(2025, 1, 1), Income: 1000, Net: 1000},
+				testDate(2025, 3, 1): {Start: testDate(2025, 3, 1), Expense: 300, Net: -300},
+			},
+			want: []entity.TimeSeriesPoint{
+				{Start: testDate(2025, 1, 1), Income: 1000, Net: 1000, CumulativeCents: 1000},
+				{Start: testDate(2025, 2, 1), CumulativeCents: 1000},
+				{Start: testDate(2025, 3, 1), Expense: 300, Net: -300, CumulativeCents: 700},
+			},
+		},
+		{
+			name:    "no entries",
+			byStart: map[time.Time]entity.TimeSeriesPoint{},
+			want:    []entity.TimeSeriesPoint{{Start: testDate(2025, 1, 1)}, {Start: testDate(2025, 2, 1)}, {Start: testDate(2025, 3, 1)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fillTimeSeries(buckets, tt.byStart)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("fillTimeSeries = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
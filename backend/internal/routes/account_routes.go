@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"manager/internal/handlers"
+	"manager/internal/repositories"
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func SetupAccountRoutes(router *gin.RouterGroup, db *pgxpool.Pool) {
+	accountRepo := repositories.NewAccountRepository(db)
+	accountUsecase := usecases.NewAccountUsecase(accountRepo)
+	accountHandler := handlers.NewAccountHandler(accountUsecase)
+
+	accounts := router.Group("/accounts")
+	{
+		accounts.GET("", accountHandler.GetAll)
+		accounts.GET("/:id", accountHandler.GetByID)
+		accounts.POST("", accountHandler.Create)
+		accounts.PUT("/:id", accountHandler.Update)
+		accounts.DELETE("/:id", accountHandler.Delete)
+	}
+}
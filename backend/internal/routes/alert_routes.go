@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"manager/internal/handlers"
+	"manager/internal/notifier"
+	"manager/internal/repositories"
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func SetupAlertRoutes(router *gin.RouterGroup, db *pgxpool.Pool) {
+	alertHandler := handlers.NewAlertHandler(newAlertUsecase(db))
+
+	alerts := router.Group("/alerts")
+	{
+		alerts.GET("", alertHandler.GetAll)
+		alerts.GET("/:id", alertHandler.GetByID)
+		alerts.POST("", alertHandler.Create)
+		alerts.PUT("/:id", alertHandler.Update)
+		alerts.DELETE("/:id", alertHandler.Delete)
+		alerts.POST("/evaluate", alertHandler.Evaluate)
+	}
+
+	notifications := router.Group("/notifications")
+	{
+		notifications.GET("", alertHandler.GetNotifications)
+		notifications.POST("/:id/read", alertHandler.MarkNotificationRead)
+		notifications.POST("/read-all", alertHandler.MarkAllNotificationsRead)
+	}
+}
+
+// newAlertUsecase monta o avaliador de alertas, também usado pelas rotas que escrevem transações
+func newAlertUsecase(db *pgxpool.Pool) *usecases.AlertUsecase {
+	transactionRepo := repositories.NewTransactionRepository(db)
+	budgetUsecase := usecases.NewBudgetUsecase(repositories.NewBudgetRepository(db), transactionRepo, repositories.NewCategoryRepository(db))
+
+	return usecases.NewAlertUsecase(
+		repositories.NewAlertRepository(db),
+		repositories.NewNotificationRepository(db),
+		budgetUsecase,
+		transactionRepo,
+		repositories.NewAccountRepository(db),
+		notifier.FromEnv(),
+	)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"manager/internal/handlers"
+	"manager/internal/repositories"
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func SetupBillRoutes(router *gin.RouterGroup, db *pgxpool.Pool) {
+	transactionRepo := repositories.NewTransactionRepository(db)
+	categoryRepo := repositories.NewCategoryRepository(db)
+	billUsecase := usecases.NewBillUsecase(transactionRepo, categoryRepo)
+	billHandler := handlers.NewBillHandler(billUsecase)
+
+	bills := router.Group("/bills")
+	{
+		bills.GET("/upcoming", billHandler.GetUpcoming)
+		bills.GET("/overdue", billHandler.GetOverdue)
+	}
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"manager/internal/handlers"
+	"manager/internal/repositories"
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func SetupBudgetRoutes(router *gin.RouterGroup, db *pgxpool.Pool) {
+	budgetRepo := repositories.NewBudgetRepository(db)
+	transactionRepo := repositories.NewTransactionRepository(db)
+	categoryRepo := repositories.NewCategoryRepository(db)
+	budgetUsecase := usecases.NewBudgetUsecase(budgetRepo, transactionRepo, categoryRepo)
+	budgetHandler := handlers.NewBudgetHandler(budgetUsecase)
+
+	budgets := router.Group("/budgets")
+	{
+		budgets.GET("", budgetHandler.GetAll)
+		budgets.GET("/report", budgetHandler.Report)
+		budgets.GET("/:id", budgetHandler.GetByID)
+		budgets.POST("", budgetHandler.Create)
+		budgets.PUT("/:id", budgetHandler.Update)
+		budgets.DELETE("/:id", budgetHandler.Delete)
+	}
+}
@@ -12,12 +12,17 @@ import (
 func SetupDashboardRoutes(router *gin.RouterGroup, db *pgxpool.Pool) {
 	transactionRepo := repositories.NewTransactionRepository(db)
 	categoryRepo := repositories.NewCategoryRepository(db)
-	dashboardUsecase := usecases.NewDashboardUsecase(transactionRepo, categoryRepo)
+	accountRepo := repositories.NewAccountRepository(db)
+	recurrenceRepo := repositories.NewRecurrenceRepository(db)
+	dashboardUsecase := usecases.NewDashboardUsecase(transactionRepo, categoryRepo, accountRepo, recurrenceRepo)
 	dashboardHandler := handlers.NewDashboardHandler(dashboardUsecase)
 
 	dashboard := router.Group("/dashboard")
 	{
 		dashboard.GET("/summary", dashboardHandler.GetSummary)
+		dashboard.GET("/timeseries", dashboardHandler.GetTimeSeries)
+		dashboard.GET("/forecast", dashboardHandler.GetForecast)
+		dashboard.GET("/categories", dashboardHandler.GetCategoryBreakdown)
 	}
 }
 
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"manager/internal/handlers"
+	"manager/internal/repositories"
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func SetupGoalRoutes(router *gin.RouterGroup, db *pgxpool.Pool) {
+	goalRepo := repositories.NewGoalRepository(db)
+	transactionRepo := repositories.NewTransactionRepository(db)
+	accountRepo := repositories.NewAccountRepository(db)
+	goalUsecase := usecases.NewGoalUsecase(goalRepo, transactionRepo, accountRepo)
+	goalHandler := handlers.NewGoalHandler(goalUsecase)
+
+	goals := router.Group("/goals")
+	{
+		goals.GET("", goalHandler.GetAll)
+		goals.GET("/:id", goalHandler.GetByID)
+		goals.POST("", goalHandler.Create)
+		goals.PUT("/:id", goalHandler.Update)
+		goals.DELETE("/:id", goalHandler.Delete)
+		goals.GET("/:id/progress", goalHandler.GetProgress)
+		goals.GET("/:id/contributions", goalHandler.ListContributions)
+		goals.POST("/:id/contributions", goalHandler.AddContribution)
+		goals.DELETE("/:id/contributions/:contribution", goalHandler.DeleteContribution)
+	}
+}
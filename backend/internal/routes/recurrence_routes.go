@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"manager/internal/handlers"
+	"manager/internal/repositories"
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func SetupRecurrenceRoutes(router *gin.RouterGroup, db *pgxpool.Pool) {
+	recurrenceRepo := repositories.NewRecurrenceRepository(db)
+	recurrenceUsecase := usecases.NewRecurrenceUsecase(recurrenceRepo)
+	recurrenceHandler := handlers.NewRecurrenceHandler(recurrenceUsecase)
+
+	recurrences := router.Group("/recurrences")
+	{
+		recurrences.GET("", recurrenceHandler.GetAll)
+		recurrences.GET("/:id", recurrenceHandler.GetByID)
+		recurrences.POST("", recurrenceHandler.Create)
+		recurrences.PUT("/:id", recurrenceHandler.Update)
+		recurrences.DELETE("/:id", recurrenceHandler.Delete)
+		recurrences.POST("/:id/pause", recurrenceHandler.Pause)
+		recurrences.POST("/:id/resume", recurrenceHandler.Resume)
+		recurrences.POST("/:id/skip", recurrenceHandler.Skip)
+		recurrences.POST("/generate", recurrenceHandler.Generate)
+	}
+}
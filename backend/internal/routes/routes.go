@@ -10,7 +10,14 @@ func SetupRoutes(router *gin.Engine, db *pgxpool.Pool) {
 	{
 		SetupTransactionRoutes(api, db)
 		SetupCategoryRoutes(api, db)
+		SetupAccountRoutes(api, db)
+		SetupStatementRoutes(api, db)
 		SetupDashboardRoutes(api, db)
+		SetupRecurrenceRoutes(api, db)
+		SetupBudgetRoutes(api, db)
+		SetupGoalRoutes(api, db)
+		SetupAlertRoutes(api, db)
+		SetupBillRoutes(api, db)
 	}
 }
 
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"manager/internal/handlers"
+	"manager/internal/repositories"
+	"manager/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func SetupStatementRoutes(router *gin.RouterGroup, db *pgxpool.Pool) {
+	transactionUsecase := usecases.NewTransactionUsecase(
+		repositories.NewUnitOfWork(db),
+		repositories.NewTransactionRepository(db),
+		repositories.NewRecurrenceRepository(db),
+		repositories.NewPaymentRepository(db),
+		repositories.NewAccountRepository(db),
+		repositories.NewStatementRepository(db),
+	)
+	statementHandler := handlers.NewStatementHandler(transactionUsecase)
+
+	// Faturas de cartões de crédito
+	statements := router.Group("/accounts/:id/statements")
+	statements.Use(handlers.EvaluateAlertsAfterWrite(newAlertUsecase(db)))
+	{
+		statements.GET("", statementHandler.GetAll)
+		statements.GET("/:statement", statementHandler.GetByID)
+		statements.POST("/:statement/pay", statementHandler.Pay)
+	}
+}
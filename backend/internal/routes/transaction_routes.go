@@ -10,18 +10,35 @@ import (
 )
 
 func SetupTransactionRoutes(router *gin.RouterGroup, db *pgxpool.Pool) {
+	unitOfWork := repositories.NewUnitOfWork(db)
 	transactionRepo := repositories.NewTransactionRepository(db)
-	transactionUsecase := usecases.NewTransactionUsecase(transactionRepo)
+	recurrenceRepo := repositories.NewRecurrenceRepository(db)
+	paymentRepo := repositories.NewPaymentRepository(db)
+	accountRepo := repositories.NewAccountRepository(db)
+	statementRepo := repositories.NewStatementRepository(db)
+	transactionUsecase := usecases.NewTransactionUsecase(unitOfWork, transactionRepo, recurrenceRepo, paymentRepo, accountRepo, statementRepo)
 	transactionHandler := handlers.NewTransactionHandler(transactionUsecase)
 
 	transactions := router.Group("/transactions")
+	transactions.Use(handlers.EvaluateAlertsAfterWrite(newAlertUsecase(db)))
 	{
 		transactions.GET("", transactionHandler.GetAll)
 		transactions.GET("/:id", transactionHandler.GetByID)
 		transactions.POST("", transactionHandler.Create)
 		transactions.PUT("/:id", transactionHandler.Update)
 		transactions.DELETE("/:id", transactionHandler.Delete)
+		transactions.POST("/:id/pay", transactionHandler.Pay)
+		transactions.POST("/:id/unpay", transactionHandler.Unpay)
+		transactions.GET("/:id/payments", transactionHandler.ListPayments)
+		transactions.POST("/:id/payments", transactionHandler.AddPayment)
+		transactions.DELETE("/:id/payments/:payment", transactionHandler.DeletePayment)
 		transactions.POST("/:id/installments/:installment/pay", transactionHandler.PayInstallment)
+		transactions.POST("/:id/installments/:installment/unpay", transactionHandler.UnpayInstallment)
+		transactions.PATCH("/:id/installments/:installment", transactionHandler.RescheduleInstallment)
+		transactions.POST("/:id/installments/cancel", transactionHandler.CancelInstallments)
+		transactions.POST("/:id/installments/payoff", transactionHandler.PayOffInstallments)
+		transactions.POST("/mark-overdue", transactionHandler.MarkOverdue)
+		transactions.POST("/installments/preview", transactionHandler.PreviewInstallments)
 	}
 }
 
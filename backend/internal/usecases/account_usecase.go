@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"manager/internal/entity"
+	"manager/internal/repositories"
+)
+
+// Código de moeda ISO 4217 (ex: BRL, USD)
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type AccountUsecase struct {
+	repo *repositories.AccountRepository
+}
+
+func NewAccountUsecase(repo *repositories.AccountRepository) *AccountUsecase {
+	return &AccountUsecase{repo: repo}
+}
+
+func (u *AccountUsecase) Create(ctx context.Context, account *entity.Account) error {
+	if err := prepareAccount(account); err != nil {
+		return err
+	}
+
+	return u.repo.Create(ctx, account)
+}
+
+func (u *AccountUsecase) GetAll(ctx context.Context, includeArchived bool) ([]entity.Account, error) {
+	return u.repo.GetAll(ctx, includeArchived)
+}
+
+func (u *AccountUsecase) GetByID(ctx context.Context, id int64) (*entity.Account, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid account id")
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *AccountUsecase) Update(ctx context.Context, account *entity.Account) error {
+	if account.ID <= 0 {
+		return fmt.Errorf("invalid account id")
+	}
+
+	if err := prepareAccount(account); err != nil {
+		return err
+	}
+
+	if err := u.repo.Update(ctx, account); err != nil {
+		return err
+	}
+
+	updated, err := u.repo.GetByID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+	*account = *updated
+
+	return nil
+}
+
+// Delete remove a conta. Contas com transações não podem ser removidas; devem ser arquivadas.
+func (u *AccountUsecase) Delete(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid account id")
+	}
+
+	count, err := u.repo.CountTransactions(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return fmt.Errorf("%w: account has %d transactions, archive it instead", entity.ErrConflict, count)
+	}
+
+	return u.repo.Delete(ctx, id)
+}
+
+// prepareAccount valida a conta e aplica os valores padrão
+func prepareAccount(account *entity.Account) error {
+	if account.Name == "" {
+		return fmt.Errorf("account name is required")
+	}
+
+	switch account.Type {
+	case "":
+		account.Type = entity.AccountTypeChecking
+	case entity.AccountTypeChecking, entity.AccountTypeSavings, entity.AccountTypeCash,
+		entity.AccountTypeCreditCard, entity.AccountTypeInvestment, entity.AccountTypeOther:
+	default:
+		return fmt.Errorf("invalid account type")
+	}
+
+	account.Currency = strings.ToUpper(account.Currency)
+	if account.Currency == "" {
+		account.Currency = "BRL"
+	}
+
+	if !currencyPattern.MatchString(account.Currency) {
+		return fmt.Errorf("invalid currency code")
+	}
+
+	// Fechamento e vencimento da fatura só se aplicam a cartões de crédito
+	if account.Type != entity.AccountTypeCreditCard {
+		account.ClosingDay = nil
+		account.DueDay = nil
+		return nil
+	}
+
+	if account.ClosingDay == nil || account.DueDay == nil {
+		return fmt.Errorf("credit card accounts require closing_day and due_day")
+	}
+
+	if *account.ClosingDay < 1 || *account.ClosingDay > 31 || *account.DueDay < 1 || *account.DueDay > 31 {
+		return fmt.Errorf("closing_day and due_day must be between 1 and 31")
+	}
+
+	return nil
+}
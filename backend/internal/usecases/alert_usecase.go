@@ -0,0 +1,338 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"manager/internal/entity"
+	"manager/internal/notifier"
+	"manager/internal/repositories"
+)
+
+type AlertUsecase struct {
+	repo             *repositories.AlertRepository
+	notificationRepo *repositories.NotificationRepository
+	budgetUsecase    *BudgetUsecase
+	transactionRepo  *repositories.TransactionRepository
+	accountRepo      *repositories.AccountRepository
+	channels         map[string]notifier.Channel
+}
+
+func NewAlertUsecase(repo *repositories.AlertRepository, notificationRepo *repositories.NotificationRepository, budgetUsecase *BudgetUsecase, transactionRepo *repositories.TransactionRepository, accountRepo *repositories.AccountRepository, channels map[string]notifier.Channel) *AlertUsecase {
+	return &AlertUsecase{
+		repo:             repo,
+		notificationRepo: notificationRepo,
+		budgetUsecase:    budgetUsecase,
+		transactionRepo:  transactionRepo,
+		accountRepo:      accountRepo,
+		channels:         channels,
+	}
+}
+
+func (u *AlertUsecase) Create(ctx context.Context, rule *entity.AlertRule) error {
+	if err := prepareAlertRule(rule); err != nil {
+		return err
+	}
+
+	return u.repo.Create(ctx, rule)
+}
+
+func (u *AlertUsecase) GetAll(ctx context.Context) ([]entity.AlertRule, error) {
+	return u.repo.GetAll(ctx, false)
+}
+
+func (u *AlertUsecase) GetByID(ctx context.Context, id int64) (*entity.AlertRule, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid alert rule id")
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *AlertUsecase) Update(ctx context.Context, rule *entity.AlertRule) error {
+	if rule.ID <= 0 {
+		return fmt.Errorf("invalid alert rule id")
+	}
+
+	if err := prepareAlertRule(rule); err != nil {
+		return err
+	}
+
+	return u.repo.Update(ctx, rule)
+}
+
+func (u *AlertUsecase) Delete(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid alert rule id")
+	}
+
+	return u.repo.Delete(ctx, id)
+}
+
+// GetNotifications lista as notificações mais recentes (limite de 100)
+func (u *AlertUsecase) GetNotifications(ctx context.Context, onlyUnread bool) ([]entity.Notification, error) {
+	return u.notificationRepo.GetAll(ctx, onlyUnread, 100)
+}
+
+func (u *AlertUsecase) MarkNotificationRead(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid notification id")
+	}
+
+	return u.notificationRepo.MarkRead(ctx, id)
+}
+
+func (u *AlertUsecase) MarkAllNotificationsRead(ctx context.Context) (int64, error) {
+	return u.notificationRepo.MarkAllRead(ctx)
+}
+
+// Evaluate avalia as regras habilitadas e grava uma notificação para cada condição atingida.
+// Cada condição notifica uma única vez (pela dedupe_key), então a avaliação pode ser repetida
+// a qualquer momento; apenas as notificações novas são entregues pelos canais da regra.
+func (u *AlertUsecase) Evaluate(ctx context.Context, now time.Time) (*entity.AlertEvaluation, error) {
+	rules, err := u.repo.GetAll(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation := &entity.AlertEvaluation{Rules: len(rules), Notifications: []entity.Notification{}}
+
+	// O relatório de orçamentos do mês é compartilhado pelas regras de orçamento
+	var report *entity.BudgetReport
+	for _, rule := range rules {
+		var candidates []entity.Notification
+		switch rule.Type {
+		case entity.AlertTypeBudgetThreshold:
+			if report == nil {
+				if report, err = u.budgetUsecase.Report(ctx, now); err != nil {
+					return evaluation, err
+				}
+			}
+			candidates = budgetNotifications(rule, report)
+		case entity.AlertTypeLowBalance:
+			candidates, err = u.lowBalanceNotifications(ctx, rule, now)
+		case entity.AlertTypeBillDue:
+			candidates, err = u.billDueNotifications(ctx, rule, now)
+		}
+		if err != nil {
+			return evaluation, err
+		}
+
+		for i := range candidates {
+			notification := &candidates[i]
+			notification.RuleID = &rule.ID
+			notification.Type = rule.Type
+
+			created, err := u.notificationRepo.CreateOnce(ctx, notification)
+			if err != nil {
+				return evaluation, err
+			}
+			if !created {
+				continue
+			}
+
+			u.deliver(ctx, rule, notification)
+			evaluation.Notifications = append(evaluation.Notifications, *notification)
+		}
+	}
+
+	return evaluation, nil
+}
+
+// deliver envia a notificação pelos canais externos da regra. Falhas de entrega não
+// interrompem a avaliação: a notificação continua disponível na lista do aplicativo.
+func (u *AlertUsecase) deliver(ctx context.Context, rule entity.AlertRule, notification *entity.Notification) {
+	for _, name := range rule.Channels {
+		if name == entity.NotificationChannelInApp {
+			continue
+		}
+
+		channel, ok := u.channels[name]
+		if !ok {
+			log.Printf("[alerts] rule %d: channel %s is not configured", rule.ID, name)
+			continue
+		}
+
+		if err := channel.Send(ctx, notification); err != nil {
+			log.Printf("[alerts] rule %d: failed to deliver notification %d via %s: %v", rule.ID, notification.ID, name, err)
+		}
+	}
+}
+
+// budgetNotifications notifica as categorias cujo gasto no mês atingiu o percentual da regra.
+// Alterar o percentual da regra permite notificar de novo no mesmo mês.
+func budgetNotifications(rule entity.AlertRule, report *entity.BudgetReport) []entity.Notification {
+	var notifications []entity.Notification
+	for _, item := range report.Items {
+		if rule.CategoryID != nil && *rule.CategoryID != item.CategoryID {
+			continue
+		}
+
+		if item.BudgetedCents <= 0 || item.PercentUsed < float64(*rule.ThresholdPercent) {
+			continue
+		}
+
+		name := fmt.Sprintf("categoria %d", item.CategoryID)
+		if item.Category != nil {
+			name = item.Category.Name
+		}
+
+		notifications = append(notifications, entity.Notification{
+			Title: fmt.Sprintf("Orçamento de %s em %.0f%%", name, item.PercentUsed),
+			Message: fmt.Sprintf("Gasto de %s em %s de um orçamento de %s (restam %s).",
+				formatMoney(item.ActualCents, "BRL"), report.Month.Format("01/2006"),
+				formatMoney(item.BudgetedCents, "BRL"), formatMoney(item.RemainingCents, "BRL")),
+			DedupeKey: fmt.Sprintf("budget:%d:%d:%d:%s", rule.ID, *rule.ThresholdPercent, item.CategoryID, report.Month.Format("2006-01")),
+		})
+	}
+
+	return notifications
+}
+
+// lowBalanceNotifications notifica, no máximo uma vez por dia, quando o saldo da conta da regra
+// (ou o saldo total, sem conta) está abaixo do mínimo
+func (u *AlertUsecase) lowBalanceNotifications(ctx context.Context, rule entity.AlertRule, now time.Time) ([]entity.Notification, error) {
+	name, currency, target := "Saldo total", "BRL", "total"
+
+	var balance int64
+	if rule.AccountID != nil {
+		account, err := u.accountRepo.GetByID(ctx, *rule.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		if account.Archived {
+			return nil, nil
+		}
+		balance = account.BalanceCents
+		name, currency, target = "Saldo de "+account.Name, account.Currency, strconv.FormatInt(account.ID, 10)
+	} else {
+		total, err := u.transactionRepo.GetTotalBalance(ctx)
+		if err != nil {
+			return nil, err
+		}
+		balance = total
+	}
+
+	if balance >= *rule.ThresholdCents {
+		return nil, nil
+	}
+
+	return []entity.Notification{{
+		Title:     fmt.Sprintf("%s abaixo de %s", name, formatMoney(*rule.ThresholdCents, currency)),
+		Message:   fmt.Sprintf("%s: %s.", name, formatMoney(balance, currency)),
+		DedupeKey: fmt.Sprintf("balance:%d:%s:%s", rule.ID, target, now.Format("2006-01-02")),
+	}}, nil
+}
+
+// billDueNotifications notifica cada despesa em aberto que vence entre hoje e days_ahead dias
+func (u *AlertUsecase) billDueNotifications(ctx context.Context, rule entity.AlertRule, now time.Time) ([]entity.Notification, error) {
+	today := truncateDay(now)
+
+	until := today.AddDate(0, 0, *rule.DaysAhead)
+	bills, err := u.transactionRepo.GetBills(ctx, entity.BillFilter{
+		DueFrom:  &today,
+		DueTo:    &until,
+		Statuses: []string{"pending", "partially_paid"},
+		Type:     entity.TransactionTypeExpense,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications []entity.Notification
+	for _, bill := range bills {
+		title := bill.Title
+		if label := installmentLabel(&bill); label != "" {
+			title = fmt.Sprintf("%s (%s)", bill.Title, label)
+		}
+
+		var installment int
+		if bill.InstallmentNumber != nil {
+			installment = *bill.InstallmentNumber
+		}
+
+		notifications = append(notifications, entity.Notification{
+			Title:     fmt.Sprintf("%s vence em %s", title, bill.DueDate.Format("02/01/2006")),
+			Message:   fmt.Sprintf("%s: %s em aberto com vencimento em %s.", title, formatMoney(bill.AmountCents, "BRL"), bill.DueDate.Format("02/01/2006")),
+			DedupeKey: fmt.Sprintf("bill:%d:%d:%d:%s", rule.ID, bill.TransactionID, installment, bill.DueDate.Format("2006-01-02")),
+		})
+	}
+
+	return notifications, nil
+}
+
+func prepareAlertRule(rule *entity.AlertRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return fmt.Errorf("alert rule name is required")
+	}
+
+	switch rule.Type {
+	case entity.AlertTypeBudgetThreshold:
+		if rule.ThresholdPercent == nil || *rule.ThresholdPercent <= 0 {
+			return fmt.Errorf("threshold_percent must be greater than zero")
+		}
+		rule.ThresholdCents, rule.AccountID, rule.DaysAhead = nil, nil, nil
+	case entity.AlertTypeLowBalance:
+		if rule.ThresholdCents == nil {
+			return fmt.Errorf("threshold_cents is required")
+		}
+		rule.ThresholdPercent, rule.CategoryID, rule.DaysAhead = nil, nil, nil
+	case entity.AlertTypeBillDue:
+		if rule.DaysAhead == nil || *rule.DaysAhead < 0 {
+			return fmt.Errorf("days_ahead must be zero or greater")
+		}
+		rule.ThresholdPercent, rule.CategoryID, rule.ThresholdCents, rule.AccountID = nil, nil, nil, nil
+	default:
+		return fmt.Errorf("invalid alert rule type: %s", rule.Type)
+	}
+
+	channels := []string{}
+	for _, channel := range rule.Channels {
+		switch channel {
+		case entity.NotificationChannelInApp, entity.NotificationChannelEmail, entity.NotificationChannelWebhook:
+		default:
+			return fmt.Errorf("invalid notification channel: %s", channel)
+		}
+		if !slices.Contains(channels, channel) {
+			channels = append(channels, channel)
+		}
+	}
+	rule.Channels = channels
+
+	if rule.Enabled == nil {
+		enabled := true
+		rule.Enabled = &enabled
+	}
+
+	return nil
+}
+
+// formatMoney formata centavos no padrão brasileiro (ex: "R$ 1.234,56"); outras moedas usam o código
+func formatMoney(cents int64, currency string) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+
+	units := strconv.FormatInt(cents/100, 10)
+	var grouped strings.Builder
+	for i, digit := range units {
+		if i > 0 && (len(units)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	symbol := currency
+	if currency == "BRL" {
+		symbol = "R$"
+	}
+
+	return fmt.Sprintf("%s%s %s,%02d", sign, symbol, grouped.String(), cents%100)
+}
@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"testing"
+
+	"manager/internal/entity"
+)
+
+func TestBudgetNotificationsDedupeKey(t *testing.T) {
+	eighty, hundred := 80, 100
+	rule := entity.AlertRule{ID: 1, Type: entity.AlertTypeBudgetThreshold, ThresholdPercent: &eighty}
+
+	report := func(month int, percentUsed float64) *entity.BudgetReport {
+		return &entity.BudgetReport{
+			Month: date(2025, 2, 1).AddDate(0, month, 0),
+			Items: []entity.BudgetReportItem{{CategoryID: 5, BudgetedCents: 100000, ActualCents: int64(percentUsed * 1000), PercentUsed: percentUsed}},
+		}
+	}
+
+	first := budgetNotifications(rule, report(0, 85))
+	if len(first) != 1 {
+		t.Fatalf("got %d notifications, want 1", len(first))
+	}
+
+	// Mais gastos no mesmo mês geram a mesma chave, que o banco recusa na segunda vez
+	if again := budgetNotifications(rule, report(0, 95)); len(again) != 1 || again[0].DedupeKey != first[0].DedupeKey {
+		t.Errorf("same month key = %v, want %s", again, first[0].DedupeKey)
+	}
+
+	if next := budgetNotifications(rule, report(1, 85)); len(next) != 1 || next[0].DedupeKey == first[0].DedupeKey {
+		t.Errorf("next month key = %v, want a key other than %s", next, first[0].DedupeKey)
+	}
+
+	rule.ThresholdPercent = &hundred
+	if below := budgetNotifications(rule, report(0, 95)); len(below) != 0 {
+		t.Errorf("got %d notifications below the threshold, want 0", len(below))
+	}
+	if reached := budgetNotifications(rule, report(0, 100)); len(reached) != 1 || reached[0].DedupeKey == first[0].DedupeKey {
+		t.Errorf("100%% rule key = %v, want a key other than %s", reached, first[0].DedupeKey)
+	}
+}
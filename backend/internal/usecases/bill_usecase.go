@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"manager/internal/entity"
+	"manager/internal/repositories"
+)
+
+// Maior janela aceita para as contas a vencer, em dias
+const maxUpcomingBillDays = 365
+
+type BillUsecase struct {
+	transactionRepo *repositories.TransactionRepository
+	categoryRepo    *repositories.CategoryRepository
+}
+
+func NewBillUsecase(transactionRepo *repositories.TransactionRepository, categoryRepo *repositories.CategoryRepository) *BillUsecase {
+	return &BillUsecase{
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+// GetUpcoming lista as transações simples e parcelas pendentes que vencem entre hoje e days dias
+// à frente, em ordem de vencimento. billType vazio traz contas a pagar e a receber.
+func (u *BillUsecase) GetUpcoming(ctx context.Context, now time.Time, days int, billType entity.TransactionType) (*entity.BillList, error) {
+	if days < 0 || days > maxUpcomingBillDays {
+		return nil, fmt.Errorf("%w: days must be between 0 and %d", entity.ErrInvalidFilter, maxUpcomingBillDays)
+	}
+
+	today := truncateDay(now)
+	until := today.AddDate(0, 0, days)
+
+	return u.list(ctx, entity.BillFilter{
+		DueFrom:  &today,
+		DueTo:    &until,
+		Statuses: []string{"pending", "partially_paid"},
+		Type:     billType,
+	})
+}
+
+// GetOverdue lista as contas em aberto com vencimento anterior a hoje, mesmo que o job de
+// atualização ainda não as tenha marcado como overdue
+func (u *BillUsecase) GetOverdue(ctx context.Context, now time.Time, billType entity.TransactionType) (*entity.BillList, error) {
+	yesterday := truncateDay(now).AddDate(0, 0, -1)
+
+	return u.list(ctx, entity.BillFilter{
+		DueTo: &yesterday,
+		Type:  billType,
+	})
+}
+
+func (u *BillUsecase) list(ctx context.Context, filter entity.BillFilter) (*entity.BillList, error) {
+	switch filter.Type {
+	case "", entity.TransactionTypeIncome, entity.TransactionTypeExpense:
+	default:
+		return nil, fmt.Errorf("%w: invalid bill type: %s", entity.ErrInvalidFilter, filter.Type)
+	}
+
+	bills, err := u.transactionRepo.GetBills(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := u.categoryRepo.GetAllFlat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryByID := make(map[int64]*entity.Category, len(categories))
+	for i := range categories {
+		categoryByID[categories[i].ID] = &categories[i]
+	}
+
+	list := &entity.BillList{From: filter.DueFrom, To: *filter.DueTo, Bills: bills}
+	for i := range list.Bills {
+		bill := &list.Bills[i]
+		bill.InstallmentLabel = installmentLabel(bill)
+		if bill.CategoryID != nil {
+			bill.Category = categoryByID[*bill.CategoryID]
+		}
+
+		if bill.Type == entity.TransactionTypeIncome {
+			list.ReceivableCents += bill.AmountCents
+		} else {
+			list.PayableCents += bill.AmountCents
+		}
+	}
+
+	return list, nil
+}
+
+// installmentLabel retorna a parcela no formato "3/10", "entrada" para a entrada (parcela 0) ou
+// vazio para transações simples
+func installmentLabel(bill *entity.Bill) string {
+	if bill.InstallmentNumber == nil || bill.TotalInstallments == nil {
+		return ""
+	}
+
+	if *bill.InstallmentNumber == 0 {
+		return "entrada"
+	}
+
+	return fmt.Sprintf("%d/%d", *bill.InstallmentNumber, *bill.TotalInstallments)
+}
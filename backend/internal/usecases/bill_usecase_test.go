@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"testing"
+
+	"manager/internal/entity"
+)
+
+func TestInstallmentLabel(t *testing.T) {
+	tests := []struct {
+		name   string
+		number *int
+		total  *int
+		want   string
+	}{
+		{"single transaction", nil, nil, ""},
+		{"installment", intPtr(3), intPtr(10), "3/10"},
+		{"last installment", intPtr(10), intPtr(10), "10/10"},
+		{"down payment", intPtr(0), intPtr(10), "entrada"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bill := &entity.Bill{InstallmentNumber: tt.number, TotalInstallments: tt.total}
+			if got := installmentLabel(bill); got != tt.want {
+				t.Errorf("installmentLabel = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"manager/internal/entity"
+	"manager/internal/repositories"
+)
+
+type BudgetUsecase struct {
+	repo            *repositories.BudgetRepository
+	transactionRepo *repositories.TransactionRepository
+	categoryRepo    *repositories.CategoryRepository
+}
+
+func NewBudgetUsecase(repo *repositories.BudgetRepository, transactionRepo *repositories.TransactionRepository, categoryRepo *repositories.CategoryRepository) *BudgetUsecase {
+	return &BudgetUsecase{
+		repo:            repo,
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+	}
+}
+
+func (u *BudgetUsecase) Create(ctx context.Context, budget *entity.Budget) error {
+	if err := u.prepareBudget(ctx, budget); err != nil {
+		return err
+	}
+
+	return u.repo.Create(ctx, budget)
+}
+
+// GetAll lista os orçamentos do mês
+func (u *BudgetUsecase) GetAll(ctx context.Context, month time.Time) ([]entity.Budget, error) {
+	return u.repo.GetByMonth(ctx, firstOfMonth(month))
+}
+
+func (u *BudgetUsecase) GetByID(ctx context.Context, id int64) (*entity.Budget, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid budget id")
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *BudgetUsecase) Update(ctx context.Context, budget *entity.Budget) error {
+	if budget.ID <= 0 {
+		return fmt.Errorf("invalid budget id")
+	}
+
+	if err := u.prepareBudget(ctx, budget); err != nil {
+		return err
+	}
+
+	return u.repo.Update(ctx, budget)
+}
+
+func (u *BudgetUsecase) Delete(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid budget id")
+	}
+
+	return u.repo.Delete(ctx, id)
+}
+
+// Report compara, para cada categoria com orçamento no mês, o orçado (limite mais o saldo
+// trazido do mês anterior) com o gasto, a partir da mesma agregação do dashboard. O gasto de uma
+// categoria inclui o das suas subcategorias; os totais do relatório contam apenas os orçamentos
+// sem orçamento em uma categoria acima, para não somar o mesmo gasto duas vezes.
+func (u *BudgetUsecase) Report(ctx context.Context, month time.Time) (*entity.BudgetReport, error) {
+	month = firstOfMonth(month)
+
+	budgets, err := u.repo.GetByMonth(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := u.categoryRepo.GetAllFlat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryByID := make(map[int64]*entity.Category, len(categories))
+	for i := range categories {
+		categoryByID[categories[i].ID] = &categories[i]
+	}
+
+	tree := newCategoryTree(categories)
+	expenses := newMonthlyExpenses(u.transactionRepo, tree)
+	actual, err := expenses.get(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+
+	budgeted := make(map[int64]bool, len(budgets))
+	for _, budget := range budgets {
+		budgeted[budget.CategoryID] = true
+	}
+
+	report := &entity.BudgetReport{Month: month, Items: []entity.BudgetReportItem{}}
+	for _, budget := range budgets {
+		rollover, err := u.rolloverInto(ctx, budget.CategoryID, month, expenses)
+		if err != nil {
+			return nil, err
+		}
+
+		item := entity.BudgetReportItem{
+			BudgetID:      budget.ID,
+			CategoryID:    budget.CategoryID,
+			Category:      categoryByID[budget.CategoryID],
+			LimitCents:    budget.LimitCents,
+			RolloverCents: rollover,
+			BudgetedCents: budget.LimitCents + rollover,
+			ActualCents:   actual[budget.CategoryID],
+		}
+		item.RemainingCents = item.BudgetedCents - item.ActualCents
+		item.PercentUsed = percentOf(item.ActualCents, item.BudgetedCents)
+
+		report.Items = append(report.Items, item)
+		if !hasBudgetedAncestor(tree, budget.CategoryID, budgeted) {
+			report.BudgetedCents += item.BudgetedCents
+			report.ActualCents += item.ActualCents
+		}
+	}
+	report.RemainingCents = report.BudgetedCents - report.ActualCents
+
+	// O gasto total do mês é a soma das categorias de primeiro nível
+	var total int64
+	for categoryID, spent := range actual {
+		if tree.root(categoryID) == categoryID {
+			total += spent
+		}
+	}
+	report.UnbudgetedCents = total - report.ActualCents
+
+	return report, nil
+}
+
+// rolloverInto calcula o saldo não gasto que chega ao mês a partir da sequência de meses
+// anteriores consecutivos com orçamento e rollover habilitado. Saldos negativos não são levados.
+func (u *BudgetUsecase) rolloverInto(ctx context.Context, categoryID int64, month time.Time, expenses *monthlyExpenses) (int64, error) {
+	history, err := u.repo.GetByCategory(ctx, categoryID, month.AddDate(0, -1, 0))
+	if err != nil {
+		return 0, err
+	}
+
+	var chain []entity.Budget
+	expected := month.AddDate(0, -1, 0)
+	for _, budget := range history {
+		if !budget.Month.Equal(expected) || !budget.Rollover {
+			break
+		}
+		chain = append(chain, budget)
+		expected = expected.AddDate(0, -1, 0)
+	}
+
+	var carry int64
+	for i := len(chain) - 1; i >= 0; i-- {
+		actual, err := expenses.get(ctx, chain[i].Month)
+		if err != nil {
+			return 0, err
+		}
+		carry = max(chain[i].LimitCents+carry-actual[categoryID], 0)
+	}
+
+	return carry, nil
+}
+
+func (u *BudgetUsecase) prepareBudget(ctx context.Context, budget *entity.Budget) error {
+	if budget.CategoryID <= 0 {
+		return fmt.Errorf("budget category is required")
+	}
+
+	if budget.LimitCents <= 0 {
+		return fmt.Errorf("budget limit must be greater than zero")
+	}
+
+	if budget.Month.IsZero() {
+		budget.Month = time.Now()
+	}
+	budget.Month = firstOfMonth(budget.Month)
+
+	exists, err := u.repo.Exists(ctx, budget.CategoryID, budget.Month, budget.ID)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return fmt.Errorf("%w: category %d already has a budget for %s", entity.ErrConflict, budget.CategoryID, budget.Month.Format("2006-01"))
+	}
+
+	return nil
+}
+
+// hasBudgetedAncestor informa se alguma categoria acima de categoryID tem orçamento no mês
+func hasBudgetedAncestor(tree *categoryTree, categoryID int64, budgeted map[int64]bool) bool {
+	for _, ancestor := range tree.ancestors(categoryID)[1:] {
+		if budgeted[ancestor] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// monthlyExpenses guarda os gastos por categoria já consultados de cada mês, já somados aos das
+// categorias pai
+type monthlyExpenses struct {
+	repo   *repositories.TransactionRepository
+	tree   *categoryTree
+	months map[time.Time]map[int64]int64
+}
+
+func newMonthlyExpenses(repo *repositories.TransactionRepository, tree *categoryTree) *monthlyExpenses {
+	return &monthlyExpenses{repo: repo, tree: tree, months: make(map[time.Time]map[int64]int64)}
+}
+
+func (m *monthlyExpenses) get(ctx context.Context, month time.Time) (map[int64]int64, error) {
+	if expenses, ok := m.months[month]; ok {
+		return expenses, nil
+	}
+
+	expenses, err := m.repo.GetCategoryExpenses(ctx, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+	expenses = m.tree.rollUp(expenses)
+
+	m.months[month] = expenses
+	return expenses, nil
+}
+
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// percentOf retorna part como percentual de total, com duas casas decimais
+func percentOf(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)*10000/float64(total)) / 100
+}
@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"manager/internal/entity"
 	"manager/internal/repositories"
@@ -29,6 +30,10 @@ func (u *CategoryUsecase) Create(ctx context.Context, category *entity.Category)
 		category.Icon = "tag"
 	}
 
+	if err := u.validateParent(ctx, category); err != nil {
+		return err
+	}
+
 	return u.repo.Create(ctx, category)
 }
 
@@ -53,6 +58,10 @@ func (u *CategoryUsecase) Update(ctx context.Context, category *entity.Category)
 		return fmt.Errorf("category name is required")
 	}
 
+	if err := u.validateParent(ctx, category); err != nil {
+		return err
+	}
+
 	return u.repo.Update(ctx, category)
 }
 
@@ -64,3 +73,91 @@ func (u *CategoryUsecase) Delete(ctx context.Context, id int64) error {
 	return u.repo.Delete(ctx, id)
 }
 
+// validateParent garante que a categoria pai existe e que ela não é a própria categoria nem uma
+// de suas subcategorias, o que criaria um ciclo na hierarquia
+func (u *CategoryUsecase) validateParent(ctx context.Context, category *entity.Category) error {
+	if category.ParentID == nil {
+		return nil
+	}
+
+	parentID := *category.ParentID
+	if parentID == category.ID {
+		return fmt.Errorf("%w: category %d cannot be its own parent", entity.ErrConflict, category.ID)
+	}
+
+	categories, err := u.repo.GetAllFlat(ctx)
+	if err != nil {
+		return err
+	}
+
+	tree := newCategoryTree(categories)
+	if !tree.exists(parentID) {
+		return fmt.Errorf("parent category %d: %w", parentID, entity.ErrNotFound)
+	}
+
+	if category.ID > 0 && slices.Contains(tree.ancestors(parentID), category.ID) {
+		return fmt.Errorf("%w: category %d cannot be moved under its subcategory %d", entity.ErrConflict, category.ID, parentID)
+	}
+
+	return nil
+}
+
+// categoryTree resolve a hierarquia das categorias para somar o gasto das subcategorias às
+// categorias pai
+type categoryTree struct {
+	parentOf map[int64]*int64
+}
+
+func newCategoryTree(categories []entity.Category) *categoryTree {
+	tree := &categoryTree{parentOf: make(map[int64]*int64, len(categories))}
+	for _, category := range categories {
+		tree.parentOf[category.ID] = category.ParentID
+	}
+
+	return tree
+}
+
+func (t *categoryTree) exists(id int64) bool {
+	_, ok := t.parentOf[id]
+	return ok
+}
+
+// ancestors retorna a categoria seguida dos seus ancestrais, até a categoria de primeiro nível.
+// Um ciclo nos dados interrompe a subida em vez de repetir categorias.
+func (t *categoryTree) ancestors(id int64) []int64 {
+	ids := []int64{id}
+	for parent := t.parentOf[id]; parent != nil && !slices.Contains(ids, *parent); parent = t.parentOf[*parent] {
+		ids = append(ids, *parent)
+	}
+
+	return ids
+}
+
+// root retorna a categoria de primeiro nível da qual id faz parte
+func (t *categoryTree) root(id int64) int64 {
+	ancestors := t.ancestors(id)
+	return ancestors[len(ancestors)-1]
+}
+
+// rollUp retorna o total de cada categoria somado ao de todas as suas subcategorias
+func (t *categoryTree) rollUp(totals map[int64]int64) map[int64]int64 {
+	rolled := make(map[int64]int64, len(totals))
+	for id, total := range totals {
+		for _, ancestor := range t.ancestors(id) {
+			rolled[ancestor] += total
+		}
+	}
+
+	return rolled
+}
+
+// rollUpToRoots soma o total de cada categoria ao da sua categoria de primeiro nível
+func (t *categoryTree) rollUpToRoots(totals map[int64]int64) map[int64]int64 {
+	rolled := make(map[int64]int64, len(totals))
+	for id, total := range totals {
+		rolled[t.root(id)] += total
+	}
+
+	return rolled
+}
+
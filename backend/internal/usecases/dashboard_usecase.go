@@ -1,57 +1,437 @@
 package usecases
 
 import (
+	"cmp"
 	"context"
-	"time"
+	"fmt"
+	"slices"
 
+	"manager/internal/entity"
 	"manager/internal/repositories"
 )
 
 type DashboardSummary struct {
-	TotalBalance     int64           `json:"total_balance"`
+	// Período das receitas, despesas e gastos por categoria. Saldos e vencidos são sempre os atuais.
+	Period       entity.Period `json:"period"`
+	TotalBalance int64         `json:"total_balance"`
+	// Receitas e despesas do período (os nomes são mantidos por compatibilidade)
 	MonthlyIncome    int64           `json:"monthly_income"`
 	MonthlyExpense   int64           `json:"monthly_expense"`
+	Net              int64           `json:"net"`
 	CategoryExpenses map[int64]int64 `json:"category_expenses"`
+	OverdueCount     int64           `json:"overdue_count"`
+	OverdueAmount    int64           `json:"overdue_amount"`
+	// Diferença entre o valor pago e o previsto (juros, multas, descontos) no período
+	IncomePaymentDifference  int64 `json:"income_payment_difference"`
+	ExpensePaymentDifference int64 `json:"expense_payment_difference"`
+	// Saldo de cada conta ativa
+	AccountBalances []entity.Account `json:"account_balances"`
+	// Período de comparação, quando solicitado
+	Comparison *DashboardComparison `json:"comparison,omitempty"`
+}
+
+// DashboardComparison traz os totais do período de comparação e as variações do período
+// principal em relação a ele (principal - comparação)
+type DashboardComparison struct {
+	Period           entity.Period   `json:"period"`
+	Income           int64           `json:"income"`
+	Expense          int64           `json:"expense"`
+	Net              int64           `json:"net"`
+	CategoryExpenses map[int64]int64 `json:"category_expenses"`
+	IncomeDelta      int64           `json:"income_delta"`
+	ExpenseDelta     int64           `json:"expense_delta"`
+	NetDelta         int64           `json:"net_delta"`
+	// Variação percentual; ausente quando o valor de comparação é zero
+	IncomeChangePercent  *float64 `json:"income_change_percent,omitempty"`
+	ExpenseChangePercent *float64 `json:"expense_change_percent,omitempty"`
+	NetChangePercent     *float64 `json:"net_change_percent,omitempty"`
+	// Variação dos gastos de cada categoria presente em qualquer um dos períodos
+	CategoryExpenseDeltas map[int64]int64 `json:"category_expense_deltas"`
 }
 
 type DashboardUsecase struct {
 	transactionRepo *repositories.TransactionRepository
 	categoryRepo    *repositories.CategoryRepository
+	accountRepo     *repositories.AccountRepository
+	recurrenceRepo  *repositories.RecurrenceRepository
 }
 
-func NewDashboardUsecase(transactionRepo *repositories.TransactionRepository, categoryRepo *repositories.CategoryRepository) *DashboardUsecase {
+func NewDashboardUsecase(transactionRepo *repositories.TransactionRepository, categoryRepo *repositories.CategoryRepository, accountRepo *repositories.AccountRepository, recurrenceRepo *repositories.RecurrenceRepository) *DashboardUsecase {
 	return &DashboardUsecase{
 		transactionRepo: transactionRepo,
 		categoryRepo:    categoryRepo,
+		accountRepo:     accountRepo,
+		recurrenceRepo:  recurrenceRepo,
 	}
 }
 
-func (u *DashboardUsecase) GetSummary(ctx context.Context) (*DashboardSummary, error) {
-	now := time.Now()
-
+// GetSummary monta o resumo do período e, se informado, a comparação com outro período
+func (u *DashboardUsecase) GetSummary(ctx context.Context, period entity.Period, comparison *entity.Period) (*DashboardSummary, error) {
 	// Buscar saldo total
 	totalBalance, err := u.transactionRepo.GetTotalBalance(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Buscar receitas e despesas do mês
-	monthlyIncome, monthlyExpense, err := u.transactionRepo.GetMonthlySummary(ctx, now.Year(), int(now.Month()))
+	// Buscar receitas, despesas e gastos por categoria do período
+	income, expense, categoryExpenses, err := u.periodTotals(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+
+	// Buscar contas vencidas
+	overdueCount, overdueAmount, err := u.transactionRepo.GetOverdueSummary(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Buscar gastos por categoria
-	categoryExpenses, err := u.transactionRepo.GetCategoryExpenses(ctx, now.Year(), int(now.Month()))
+	// Buscar diferenças entre valores pagos e previstos
+	incomeDifference, expenseDifference, err := u.transactionRepo.GetPaymentDifferences(ctx, period.From, period.End())
 	if err != nil {
 		return nil, err
 	}
 
-	return &DashboardSummary{
+	// Buscar saldo por conta
+	accountBalances, err := u.accountRepo.GetAll(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &DashboardSummary{
+		Period:           period,
 		TotalBalance:     totalBalance,
-		MonthlyIncome:    monthlyIncome,
-		MonthlyExpense:   monthlyExpense,
+		MonthlyIncome:    income,
+		MonthlyExpense:   expense,
+		Net:              income - expense,
 		CategoryExpenses: categoryExpenses,
+		OverdueCount:     overdueCount,
+		OverdueAmount:    overdueAmount,
+
+		IncomePaymentDifference:  incomeDifference,
+		ExpensePaymentDifference: expenseDifference,
+		AccountBalances:          accountBalances,
+	}
+
+	if comparison != nil {
+		summary.Comparison, err = u.compare(ctx, summary, *comparison)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return summary, nil
+}
+
+func (u *DashboardUsecase) periodTotals(ctx context.Context, period entity.Period) (int64, int64, map[int64]int64, error) {
+	income, expense, err := u.transactionRepo.GetPeriodSummary(ctx, period.From, period.End())
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	categoryExpenses, err := u.transactionRepo.GetCategoryExpenses(ctx, period.From, period.End())
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	tree, err := u.categoryTree(ctx)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	// Os gastos das subcategorias entram na categoria de primeiro nível
+	return income, expense, tree.rollUpToRoots(categoryExpenses), nil
+}
+
+func (u *DashboardUsecase) categoryTree(ctx context.Context) (*categoryTree, error) {
+	categories, err := u.categoryRepo.GetAllFlat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCategoryTree(categories), nil
+}
+
+func (u *DashboardUsecase) compare(ctx context.Context, summary *DashboardSummary, period entity.Period) (*DashboardComparison, error) {
+	income, expense, categoryExpenses, err := u.periodTotals(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &DashboardComparison{
+		Period:           period,
+		Income:           income,
+		Expense:          expense,
+		Net:              income - expense,
+		CategoryExpenses: categoryExpenses,
+		IncomeDelta:      summary.MonthlyIncome - income,
+		ExpenseDelta:     summary.MonthlyExpense - expense,
+		NetDelta:         summary.Net - (income - expense),
+
+		IncomeChangePercent:   changePercent(summary.MonthlyIncome, income),
+		ExpenseChangePercent:  changePercent(summary.MonthlyExpense, expense),
+		NetChangePercent:      changePercent(summary.Net, income-expense),
+		CategoryExpenseDeltas: make(map[int64]int64),
+	}
+
+	for categoryID, total := range summary.CategoryExpenses {
+		comparison.CategoryExpenseDeltas[categoryID] = total - categoryExpenses[categoryID]
+	}
+	for categoryID, total := range categoryExpenses {
+		if _, ok := summary.CategoryExpenses[categoryID]; !ok {
+			comparison.CategoryExpenseDeltas[categoryID] = -total
+		}
+	}
+
+	return comparison, nil
+}
+
+// changePercent retorna a variação percentual de previous para current, com duas casas decimais.
+// A base é o valor absoluto de previous, para que saldos negativos que melhoram tenham variação positiva.
+func changePercent(current, previous int64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+
+	change := percentOf(current-previous, max(previous, -previous))
+	return &change
+}
+
+// Limite de intervalos de uma série temporal, para evitar respostas enormes (ex: dias em vários anos)
+const maxTimeSeriesPoints = 1000
+
+// GetTimeSeries retorna receitas, despesas, resultado e saldo acumulado por intervalo do período,
+// opcionalmente também por categoria ou conta
+func (u *DashboardUsecase) GetTimeSeries(ctx context.Context, period entity.Period, granularity string, groupBy string) (*entity.TimeSeries, error) {
+	if granularity == "" {
+		granularity = entity.TimeSeriesMonth
+	}
+
+	var days int
+	switch granularity {
+	case entity.TimeSeriesDay:
+		days = 1
+	case entity.TimeSeriesWeek:
+		days = 7
+	case entity.TimeSeriesMonth:
+		days = 28
+	default:
+		return nil, fmt.Errorf("%w: invalid granularity %s", entity.ErrInvalidFilter, granularity)
+	}
+
+	if int(period.End().Sub(period.From).Hours()/24)/days > maxTimeSeriesPoints {
+		return nil, fmt.Errorf("%w: period too long for %s granularity", entity.ErrInvalidFilter, granularity)
+	}
+
+	points, groups, err := u.transactionRepo.GetTimeSeries(ctx, period.From, period.End(), granularity, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if groupBy == entity.TimeSeriesGroupByCategory {
+		tree, err := u.categoryTree(ctx)
+		if err != nil {
+			return nil, err
+		}
+		groups = rollUpTimeSeriesGroups(tree, groups)
+	}
+
+	if err := u.nameTimeSeriesGroups(ctx, groupBy, groups); err != nil {
+		return nil, err
+	}
+
+	return &entity.TimeSeries{
+		Period:      period,
+		Granularity: granularity,
+		GroupBy:     groupBy,
+		Points:      points,
+		Groups:      groups,
+	}, nil
+}
+
+func (u *DashboardUsecase) nameTimeSeriesGroups(ctx context.Context, groupBy string, groups []entity.TimeSeriesGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	names := make(map[int64]string)
+	unnamed := "Sem categoria"
+	switch groupBy {
+	case entity.TimeSeriesGroupByCategory:
+		categories, err := u.categoryRepo.GetAllFlat(ctx)
+		if err != nil {
+			return err
+		}
+		for _, category := range categories {
+			names[category.ID] = category.Name
+		}
+	case entity.TimeSeriesGroupByAccount:
+		accounts, err := u.accountRepo.GetAll(ctx, true)
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			names[account.ID] = account.Name
+		}
+		unnamed = "Sem conta"
+	}
+
+	for i := range groups {
+		if groups[i].ID == nil {
+			groups[i].Name = unnamed
+		} else {
+			groups[i].Name = names[*groups[i].ID]
+		}
+	}
+
+	return nil
+}
+
+// rollUpTimeSeriesGroups soma as séries das subcategorias à da categoria de primeiro nível,
+// mantendo os grupos ordenados com os lançamentos sem categoria primeiro
+func rollUpTimeSeriesGroups(tree *categoryTree, groups []entity.TimeSeriesGroup) []entity.TimeSeriesGroup {
+	merged := []entity.TimeSeriesGroup{}
+	indexByRoot := make(map[int64]int)
+	for _, group := range groups {
+		if group.ID == nil {
+			merged = append(merged, group)
+			continue
+		}
+
+		root := tree.root(*group.ID)
+		index, ok := indexByRoot[root]
+		if !ok {
+			indexByRoot[root] = len(merged)
+			merged = append(merged, entity.TimeSeriesGroup{ID: &root, Points: group.Points})
+			continue
+		}
+
+		points := slices.Clone(merged[index].Points)
+		for k, point := range group.Points {
+			points[k].Income += point.Income
+			points[k].Expense += point.Expense
+			points[k].Net += point.Net
+			points[k].CumulativeCents += point.CumulativeCents
+		}
+		merged[index].Points = points
+	}
+
+	slices.SortStableFunc(merged, func(a, b entity.TimeSeriesGroup) int {
+		switch {
+		case a.ID == nil && b.ID == nil:
+			return 0
+		case a.ID == nil:
+			return -1
+		case b.ID == nil:
+			return 1
+		}
+		return cmp.Compare(*a.ID, *b.ID)
+	})
+
+	return merged
+}
+
+// GetCategoryBreakdown divide as despesas e receitas do período por categoria, com a participação
+// de cada uma no total. As categorias de primeiro nível somam o total das subcategorias, listadas
+// em children.
+func (u *DashboardUsecase) GetCategoryBreakdown(ctx context.Context, period entity.Period) (*entity.CategoryBreakdown, error) {
+	expense, income, err := u.transactionRepo.GetCategoryBreakdown(ctx, period.From, period.End())
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := u.categoryRepo.GetAllFlat(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.CategoryBreakdown{
+		Period:  period,
+		Expense: breakdownSide(expense, categories),
+		Income:  breakdownSide(income, categories),
 	}, nil
 }
 
+// breakdownSide monta a árvore de categorias com lançamentos a partir dos totais de cada
+// categoria, do maior para o menor total em cada nível
+func breakdownSide(direct []entity.CategoryBreakdownItem, categories []entity.Category) entity.CategoryBreakdownSide {
+	tree := newCategoryTree(categories)
+	categoryByID := make(map[int64]entity.Category, len(categories))
+	for _, category := range categories {
+		categoryByID[category.ID] = category
+	}
+
+	side := entity.CategoryBreakdownSide{Items: []entity.CategoryBreakdownItem{}}
+	totals := make(map[int64]int64)
+	counts := make(map[int64]int64)
+	for _, item := range direct {
+		side.TotalCents += item.TotalCents
+		if item.CategoryID == nil {
+			side.Items = append(side.Items, item)
+			continue
+		}
+
+		totals[*item.CategoryID] = item.TotalCents
+		counts[*item.CategoryID] = item.TransactionCount
+		if _, ok := categoryByID[*item.CategoryID]; !ok {
+			categoryByID[*item.CategoryID] = entity.Category{ID: *item.CategoryID, Name: item.Name, Color: item.Color, Icon: item.Icon}
+		}
+	}
+	totals, counts = tree.rollUp(totals), tree.rollUp(counts)
+
+	childrenOf := make(map[int64][]int64)
+	var roots []int64
+	for id := range totals {
+		if parent := tree.parentOf[id]; parent != nil && *parent != id {
+			childrenOf[*parent] = append(childrenOf[*parent], id)
+		} else {
+			roots = append(roots, id)
+		}
+	}
+
+	var build func(ids []int64) []entity.CategoryBreakdownItem
+	build = func(ids []int64) []entity.CategoryBreakdownItem {
+		items := make([]entity.CategoryBreakdownItem, 0, len(ids))
+		for _, id := range ids {
+			category := categoryByID[id]
+			items = append(items, entity.CategoryBreakdownItem{
+				CategoryID:       &category.ID,
+				Name:             category.Name,
+				Color:            category.Color,
+				Icon:             category.Icon,
+				TotalCents:       totals[id],
+				Percent:          percentOf(totals[id], side.TotalCents),
+				TransactionCount: counts[id],
+				Children:         build(childrenOf[id]),
+			})
+		}
+		sortBreakdownItems(items)
+		return items
+	}
+
+	for i := range side.Items {
+		side.Items[i].Percent = percentOf(side.Items[i].TotalCents, side.TotalCents)
+	}
+	side.Items = append(build(roots), side.Items...)
+	sortBreakdownItems(side.Items)
+
+	return side
+}
+
+// sortBreakdownItems ordena do maior para o menor total; em caso de empate, o grupo sem
+// categoria fica por último
+func sortBreakdownItems(items []entity.CategoryBreakdownItem) {
+	slices.SortFunc(items, func(a, b entity.CategoryBreakdownItem) int {
+		if c := cmp.Compare(b.TotalCents, a.TotalCents); c != 0 {
+			return c
+		}
+		switch {
+		case a.CategoryID == nil && b.CategoryID == nil:
+			return 0
+		case a.CategoryID == nil:
+			return 1
+		case b.CategoryID == nil:
+			return -1
+		}
+		return cmp.Compare(*a.CategoryID, *b.CategoryID)
+	})
+}
@@ -0,0 +1,36 @@
+package usecases
+
+import "testing"
+
+func TestChangePercent(t *testing.T) {
+	tests := []struct {
+		name              string
+		current, previous int64
+		want              *float64
+	}{
+		{"increase", 15000, 10000, float64Ptr(50)},
+		{"decrease", 7500, 10000, float64Ptr(-25)},
+		{"rounded to two decimals", 10000, 30000, float64Ptr(-66.67)},
+		{"negative net that improves", -5000, -10000, float64Ptr(50)},
+		{"negative net that gets worse", -15000, -10000, float64Ptr(-50)},
+		{"no previous value", 10000, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := changePercent(tt.current, tt.previous)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("changePercent(%d, %d) = %v, want %v", tt.current, tt.previous, deref(got), deref(tt.want))
+			}
+		})
+	}
+}
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func deref(v *float64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
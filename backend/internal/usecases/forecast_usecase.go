@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"manager/internal/entity"
+)
+
+// Horizonte máximo da previsão de fluxo de caixa, em dias
+const maxForecastDays = 365
+
+// GetForecast projeta o saldo ao fim de cada dia, de hoje até days dias à frente. Parte do saldo
+// total atual (apenas valores quitados) e soma o saldo em aberto das transações simples e parcelas,
+// além das ocorrências ainda não geradas das recorrências ativas. Lançamentos em atraso são
+// projetados no primeiro dia, a menos que includeOverdue seja falso.
+func (u *DashboardUsecase) GetForecast(ctx context.Context, now time.Time, days int, includeOverdue bool) (*entity.CashFlowForecast, error) {
+	if days < 1 || days > maxForecastDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", entity.ErrInvalidFilter, maxForecastDays)
+	}
+
+	today := truncateDay(now)
+	until := today.AddDate(0, 0, days-1)
+
+	balance, err := u.transactionRepo.GetTotalBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := entity.BillFilter{DueTo: &until}
+	if !includeOverdue {
+		filter.DueFrom = &today
+	}
+
+	bills, err := u.transactionRepo.GetBills(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsByDay := make(map[time.Time][]entity.ForecastItem)
+	for _, bill := range bills {
+		item := entity.ForecastItem{
+			Source:        entity.ForecastSourceTransaction,
+			TransactionID: &bill.TransactionID,
+			Title:         bill.Title,
+			Type:          bill.Type,
+			AmountCents:   bill.AmountCents,
+			DueDate:       bill.DueDate,
+		}
+		if bill.InstallmentNumber != nil {
+			item.Source = entity.ForecastSourceInstallment
+			item.InstallmentNumber = bill.InstallmentNumber
+		}
+
+		day := truncateDay(bill.DueDate)
+		if day.Before(today) {
+			day = today
+		}
+		itemsByDay[day] = append(itemsByDay[day], item)
+	}
+
+	recurrences, err := u.recurrenceRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range recurrences {
+		for _, item := range projectRecurrence(recurrences[i], today, until) {
+			day := truncateDay(item.DueDate)
+			itemsByDay[day] = append(itemsByDay[day], item)
+		}
+	}
+
+	forecast := &entity.CashFlowForecast{
+		From:                 today,
+		To:                   until,
+		StartingBalanceCents: balance,
+		LowestBalanceCents:   balance,
+		LowestBalanceDate:    today,
+		NegativeDates:        []time.Time{},
+		Days:                 make([]entity.ForecastDay, 0, days),
+	}
+
+	for date := today; !date.After(until); date = date.AddDate(0, 0, 1) {
+		day := entity.ForecastDay{Date: date, Items: itemsByDay[date]}
+		for _, item := range day.Items {
+			if item.Type == entity.TransactionTypeIncome {
+				day.IncomeCents += item.AmountCents
+			} else {
+				day.ExpenseCents += item.AmountCents
+			}
+		}
+
+		balance += day.IncomeCents - day.ExpenseCents
+		day.BalanceCents = balance
+		day.Negative = balance < 0
+
+		if balance < forecast.LowestBalanceCents {
+			forecast.LowestBalanceCents = balance
+			forecast.LowestBalanceDate = date
+		}
+
+		if day.Negative {
+			if forecast.FirstNegativeDate == nil {
+				first := date
+				forecast.FirstNegativeDate = &first
+			}
+			forecast.NegativeDates = append(forecast.NegativeDates, date)
+		}
+
+		forecast.Days = append(forecast.Days, day)
+	}
+	forecast.EndingBalanceCents = balance
+
+	return forecast, nil
+}
+
+// projectRecurrence lista as ocorrências da série entre from e until que ainda não foram geradas
+// como transações (a partir de next_due_date), seguindo as mesmas regras do gerador
+func projectRecurrence(recurrence entity.Recurrence, from, until time.Time) []entity.ForecastItem {
+	if recurrence.Status != entity.RecurrenceStatusActive {
+		return nil
+	}
+
+	var items []entity.ForecastItem
+	for date := truncateDay(recurrence.NextDueDate); !date.After(until); date = nextOccurrence(&recurrence, date) {
+		if !recurrenceHasNext(&recurrence, date) {
+			break
+		}
+		recurrence.OccurrencesCount++
+
+		if date.Before(from) {
+			continue
+		}
+
+		items = append(items, entity.ForecastItem{
+			Source:       entity.ForecastSourceRecurrence,
+			RecurrenceID: &recurrence.ID,
+			Title:        recurrence.Title,
+			Type:         recurrence.Type,
+			AmountCents:  recurrence.AmountCents,
+			DueDate:      date,
+		})
+	}
+
+	return items
+}
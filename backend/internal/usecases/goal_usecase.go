@@ -0,0 +1,296 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"manager/internal/entity"
+	"manager/internal/repositories"
+)
+
+type GoalUsecase struct {
+	repo            *repositories.GoalRepository
+	transactionRepo *repositories.TransactionRepository
+	accountRepo     *repositories.AccountRepository
+}
+
+func NewGoalUsecase(repo *repositories.GoalRepository, transactionRepo *repositories.TransactionRepository, accountRepo *repositories.AccountRepository) *GoalUsecase {
+	return &GoalUsecase{
+		repo:            repo,
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+func (u *GoalUsecase) Create(ctx context.Context, goal *entity.Goal) error {
+	if err := prepareGoal(goal); err != nil {
+		return err
+	}
+
+	if err := u.checkAccount(ctx, goal.AccountID, nil); err != nil {
+		return err
+	}
+
+	return u.repo.Create(ctx, goal)
+}
+
+func (u *GoalUsecase) GetAll(ctx context.Context) ([]entity.Goal, error) {
+	return u.repo.GetAll(ctx)
+}
+
+func (u *GoalUsecase) GetByID(ctx context.Context, id int64) (*entity.Goal, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid goal id")
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *GoalUsecase) Update(ctx context.Context, goal *entity.Goal) error {
+	if goal.ID <= 0 {
+		return fmt.Errorf("invalid goal id")
+	}
+
+	if err := prepareGoal(goal); err != nil {
+		return err
+	}
+
+	current, err := u.repo.GetByID(ctx, goal.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.checkAccount(ctx, goal.AccountID, current.AccountID); err != nil {
+		return err
+	}
+
+	if err := u.repo.Update(ctx, goal); err != nil {
+		return err
+	}
+
+	// Recarregar para devolver o total aportado
+	updated, err := u.repo.GetByID(ctx, goal.ID)
+	if err != nil {
+		return err
+	}
+	*goal = *updated
+
+	return nil
+}
+
+func (u *GoalUsecase) Delete(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid goal id")
+	}
+
+	return u.repo.Delete(ctx, id)
+}
+
+func (u *GoalUsecase) ListContributions(ctx context.Context, goalID int64) ([]entity.GoalContribution, error) {
+	if _, err := u.GetByID(ctx, goalID); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetContributions(ctx, goalID)
+}
+
+// AddContribution registra um aporte na meta. Quando vinculado a uma transação, o valor e a data
+// não informados são os da transação (valor pago, se houver, e vencimento).
+func (u *GoalUsecase) AddContribution(ctx context.Context, goalID int64, contribution *entity.GoalContribution) error {
+	goal, err := u.GetByID(ctx, goalID)
+	if err != nil {
+		return err
+	}
+	contribution.GoalID = goalID
+
+	if contribution.TransactionID != nil {
+		transaction, err := u.transactionRepo.GetByID(ctx, *contribution.TransactionID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkContributionTransaction(goal, transaction); err != nil {
+			return err
+		}
+
+		exists, err := u.repo.HasTransaction(ctx, goalID, transaction.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("%w: transaction %d is already a contribution to goal %d", entity.ErrConflict, transaction.ID, goalID)
+		}
+
+		if contribution.AmountCents == 0 {
+			contribution.AmountCents = transaction.AmountCents
+			if transaction.PaidAmountCents != nil {
+				contribution.AmountCents = *transaction.PaidAmountCents
+			}
+		}
+
+		if contribution.ContributedAt.IsZero() {
+			contribution.ContributedAt = transaction.DueDate
+		}
+	}
+
+	if contribution.AmountCents == 0 {
+		return fmt.Errorf("contribution amount must not be zero")
+	}
+
+	if contribution.ContributedAt.IsZero() {
+		contribution.ContributedAt = time.Now()
+	}
+	contribution.ContributedAt = truncateDay(contribution.ContributedAt)
+
+	return u.repo.CreateContribution(ctx, contribution)
+}
+
+func (u *GoalUsecase) DeleteContribution(ctx context.Context, goalID int64, contributionID int64) error {
+	if goalID <= 0 || contributionID <= 0 {
+		return fmt.Errorf("invalid goal contribution id")
+	}
+
+	return u.repo.DeleteContribution(ctx, goalID, contributionID)
+}
+
+// GetProgress calcula o andamento da meta em relação à data de hoje
+func (u *GoalUsecase) GetProgress(ctx context.Context, goalID int64, now time.Time) (*entity.GoalProgress, error) {
+	goal, err := u.GetByID(ctx, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	contributions, err := u.repo.GetContributions(ctx, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return goalProgress(goal, contributions, truncateDay(now)), nil
+}
+
+// goalProgress projeta a conclusão da meta mantendo a média mensal de aportes desde o mês do
+// primeiro aporte até o mês atual. Metas já concluídas usam a data do aporte que atingiu o alvo.
+func goalProgress(goal *entity.Goal, contributions []entity.GoalContribution, today time.Time) *entity.GoalProgress {
+	progress := &entity.GoalProgress{
+		GoalID:          goal.ID,
+		TargetCents:     goal.TargetCents,
+		SavedCents:      goal.SavedCents,
+		RemainingCents:  max(goal.TargetCents-goal.SavedCents, 0),
+		PercentComplete: min(percentOf(goal.SavedCents, goal.TargetCents), 100),
+		Completed:       goal.SavedCents >= goal.TargetCents,
+		TargetDate:      goal.TargetDate,
+	}
+
+	if len(contributions) > 0 {
+		months := monthsBetween(contributions[0].ContributedAt, today) + 1
+		progress.AverageMonthlyCents = goal.SavedCents / int64(max(months, 1))
+	}
+
+	if progress.Completed {
+		// Última vez em que o total atingiu o alvo (resgates podem tê-lo reduzido antes)
+		var saved int64
+		for _, contribution := range contributions {
+			before := saved
+			saved += contribution.AmountCents
+			if before < goal.TargetCents && saved >= goal.TargetCents {
+				completedAt := contribution.ContributedAt
+				progress.ProjectedCompletionDate = &completedAt
+			}
+		}
+	} else if progress.AverageMonthlyCents > 0 {
+		months := ceilDiv(progress.RemainingCents, progress.AverageMonthlyCents)
+		projected := addMonthsClamped(today, int(months), today.Day())
+		progress.ProjectedCompletionDate = &projected
+	}
+
+	if goal.TargetDate != nil {
+		monthsRemaining := 0
+		if !goal.TargetDate.Before(today) {
+			monthsRemaining = monthsBetween(today, *goal.TargetDate) + 1
+		}
+		progress.MonthsRemaining = &monthsRemaining
+
+		required := progress.RemainingCents
+		if monthsRemaining > 0 {
+			required = ceilDiv(progress.RemainingCents, int64(monthsRemaining))
+		}
+		progress.RequiredMonthlyCents = &required
+
+		onTrack := progress.Completed ||
+			(progress.ProjectedCompletionDate != nil && !progress.ProjectedCompletionDate.After(*goal.TargetDate))
+		progress.OnTrack = &onTrack
+	}
+
+	return progress
+}
+
+// checkAccount garante que a conta da meta existe e não está arquivada (exceto se já era a conta da meta)
+func (u *GoalUsecase) checkAccount(ctx context.Context, accountID *int64, currentAccountID *int64) error {
+	if accountID == nil {
+		return nil
+	}
+
+	account, err := u.accountRepo.GetByID(ctx, *accountID)
+	if err != nil {
+		return err
+	}
+
+	if account.Archived && (currentAccountID == nil || *currentAccountID != account.ID) {
+		return fmt.Errorf("%w: account %d is archived", entity.ErrConflict, account.ID)
+	}
+
+	return nil
+}
+
+// checkContributionTransaction garante que a transação vinculada representa dinheiro guardado:
+// uma transação paga que seja uma transferência para a conta da meta ou, em metas sem conta,
+// uma despesa (o valor separado para a meta)
+func checkContributionTransaction(goal *entity.Goal, transaction *entity.Transaction) error {
+	if transaction.Status != entity.TransactionStatusPaid {
+		return fmt.Errorf("%w: transaction %d is not paid", entity.ErrConflict, transaction.ID)
+	}
+
+	switch transaction.Type {
+	case entity.TransactionTypeTransfer:
+		if goal.AccountID == nil || transaction.DestinationAccountID == nil || *transaction.DestinationAccountID != *goal.AccountID {
+			return fmt.Errorf("%w: transfer %d is not into the goal account", entity.ErrConflict, transaction.ID)
+		}
+	case entity.TransactionTypeExpense:
+		if goal.AccountID != nil {
+			return fmt.Errorf("%w: goal %d only accepts transfers into account %d", entity.ErrConflict, goal.ID, *goal.AccountID)
+		}
+	default:
+		return fmt.Errorf("%w: %s transactions cannot be goal contributions", entity.ErrConflict, transaction.Type)
+	}
+
+	return nil
+}
+
+func prepareGoal(goal *entity.Goal) error {
+	goal.Name = strings.TrimSpace(goal.Name)
+	if goal.Name == "" {
+		return fmt.Errorf("goal name is required")
+	}
+
+	if goal.TargetCents <= 0 {
+		return fmt.Errorf("goal target must be greater than zero")
+	}
+
+	if goal.TargetDate != nil {
+		targetDate := truncateDay(*goal.TargetDate)
+		goal.TargetDate = &targetDate
+	}
+
+	return nil
+}
+
+// monthsBetween conta os meses de calendário entre from e to (0 no mesmo mês)
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
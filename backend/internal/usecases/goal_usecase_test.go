@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"manager/internal/entity"
+)
+
+func TestCheckContributionTransaction(t *testing.T) {
+	savings, checking := int64(1), int64(2)
+
+	tests := []struct {
+		name        string
+		goalAccount *int64
+		transaction entity.Transaction
+		wantErr     bool
+	}{
+		{
+			name:        "paid transfer into the goal account",
+			goalAccount: &savings,
+			transaction: entity.Transaction{Type: entity.TransactionTypeTransfer, Status: entity.TransactionStatusPaid, AccountID: &checking, DestinationAccountID: &savings},
+		},
+		{
+			name:        "pending transfer into the goal account",
+			goalAccount: &savings,
+			transaction: entity.Transaction{Type: entity.TransactionTypeTransfer, Status: entity.TransactionStatusPending, AccountID: &checking, DestinationAccountID: &savings},
+			wantErr:     true,
+		},
+		{
+			name:        "transfer into another account",
+			goalAccount: &savings,
+			transaction: entity.Transaction{Type: entity.TransactionTypeTransfer, Status: entity.TransactionStatusPaid, AccountID: &savings, DestinationAccountID: &checking},
+			wantErr:     true,
+		},
+		{
+			name:        "transfer to a goal without account",
+			transaction: entity.Transaction{Type: entity.TransactionTypeTransfer, Status: entity.TransactionStatusPaid, AccountID: &checking, DestinationAccountID: &savings},
+			wantErr:     true,
+		},
+		{
+			name:        "paid expense for a goal without account",
+			transaction: entity.Transaction{Type: entity.TransactionTypeExpense, Status: entity.TransactionStatusPaid},
+		},
+		{
+			name:        "partially paid expense",
+			transaction: entity.Transaction{Type: entity.TransactionTypeExpense, Status: entity.TransactionStatusPartiallyPaid},
+			wantErr:     true,
+		},
+		{
+			name:        "expense for a goal with account",
+			goalAccount: &savings,
+			transaction: entity.Transaction{Type: entity.TransactionTypeExpense, Status: entity.TransactionStatusPaid, AccountID: &checking},
+			wantErr:     true,
+		},
+		{
+			name:        "paid income",
+			transaction: entity.Transaction{Type: entity.TransactionTypeIncome, Status: entity.TransactionStatusPaid},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goal := &entity.Goal{ID: 1, AccountID: tt.goalAccount}
+			err := checkContributionTransaction(goal, &tt.transaction)
+			if tt.wantErr && !errors.Is(err, entity.ErrConflict) {
+				t.Errorf("error = %v, want %v", err, entity.ErrConflict)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
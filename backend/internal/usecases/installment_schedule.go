@@ -0,0 +1,229 @@
+package usecases
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"manager/internal/entity"
+)
+
+// prepareInstallmentSchedule valida o agendamento e as condições do parcelamento e aplica os valores padrão
+func prepareInstallmentSchedule(transaction *entity.Transaction) error {
+	if !transaction.IsInstallment {
+		transaction.InstallmentSchedule = ""
+		transaction.InstallmentIntervalDays = nil
+		transaction.InstallmentDates = nil
+		transaction.InterestRate = 0
+		transaction.AmortizationMethod = ""
+		transaction.DownPaymentCents = 0
+		transaction.RemainderPolicy = ""
+		return nil
+	}
+
+	if transaction.InstallmentSchedule == "" {
+		transaction.InstallmentSchedule = entity.InstallmentScheduleMonthly
+	}
+
+	switch transaction.InstallmentSchedule {
+	case entity.InstallmentScheduleMonthly, entity.InstallmentScheduleWeekly:
+		transaction.InstallmentIntervalDays = nil
+		transaction.InstallmentDates = nil
+	case entity.InstallmentScheduleEveryNDays:
+		if transaction.InstallmentIntervalDays == nil || *transaction.InstallmentIntervalDays <= 0 {
+			return fmt.Errorf("installment interval days must be greater than zero")
+		}
+		transaction.InstallmentDates = nil
+	case entity.InstallmentScheduleCustom:
+		transaction.InstallmentIntervalDays = nil
+		if len(transaction.InstallmentDates) != transaction.TotalInstallments {
+			return fmt.Errorf("custom schedule requires exactly %d installment dates", transaction.TotalInstallments)
+		}
+		for i := range transaction.InstallmentDates {
+			transaction.InstallmentDates[i] = truncateDay(transaction.InstallmentDates[i])
+			if i > 0 && !transaction.InstallmentDates[i].After(transaction.InstallmentDates[i-1]) {
+				return fmt.Errorf("installment dates must be in ascending order")
+			}
+		}
+		// Sem entrada, o vencimento da transação é o da primeira parcela. Com entrada, ela vence na
+		// data da transação, que não pode ser posterior à primeira parcela.
+		if transaction.DownPaymentCents == 0 {
+			transaction.DueDate = transaction.InstallmentDates[0]
+		} else if truncateDay(transaction.DueDate).After(transaction.InstallmentDates[0]) {
+			return fmt.Errorf("%w: down payment date must not be after the first installment date", entity.ErrInvalidFilter)
+		}
+	default:
+		return fmt.Errorf("invalid installment schedule")
+	}
+
+	if transaction.InterestRate < 0 {
+		return fmt.Errorf("interest rate cannot be negative")
+	}
+
+	if transaction.AmortizationMethod == "" {
+		transaction.AmortizationMethod = entity.AmortizationMethodPrice
+	}
+
+	if transaction.AmortizationMethod != entity.AmortizationMethodPrice && transaction.AmortizationMethod != entity.AmortizationMethodSAC {
+		return fmt.Errorf("invalid amortization method")
+	}
+
+	if transaction.DownPaymentCents < 0 || transaction.DownPaymentCents >= transaction.AmountCents {
+		return fmt.Errorf("down payment must be between zero and the transaction amount")
+	}
+
+	if transaction.RemainderPolicy == "" {
+		transaction.RemainderPolicy = entity.RemainderPolicyFirst
+	}
+
+	switch transaction.RemainderPolicy {
+	case entity.RemainderPolicyFirst, entity.RemainderPolicyLast, entity.RemainderPolicySpread:
+	default:
+		return fmt.Errorf("invalid remainder policy")
+	}
+
+	return nil
+}
+
+// installmentDueDates calcula o vencimento de cada parcela a partir do agendamento da transação.
+// Com entrada, a entrada vence na data da transação e as parcelas começam um período depois.
+func installmentDueDates(transaction *entity.Transaction) []time.Time {
+	dates := make([]time.Time, transaction.TotalInstallments)
+	first := transaction.DueDate
+
+	offset := 0
+	if transaction.DownPaymentCents > 0 {
+		offset = 1
+	}
+
+	for i := range dates {
+		period := i + offset
+		switch transaction.InstallmentSchedule {
+		case entity.InstallmentScheduleCustom:
+			dates[i] = transaction.InstallmentDates[i]
+		case entity.InstallmentScheduleWeekly:
+			dates[i] = first.AddDate(0, 0, 7*period)
+		case entity.InstallmentScheduleEveryNDays:
+			dates[i] = first.AddDate(0, 0, *transaction.InstallmentIntervalDays*period)
+		default:
+			// Sempre a partir da primeira data, para que 31/01 gere 28/02 e depois 31/03
+			dates[i] = addMonthsClamped(first, period, first.Day())
+		}
+	}
+
+	return dates
+}
+
+// buildInstallmentPlan calcula o cronograma completo (entrada, parcelas, juros e saldo devedor).
+// A taxa de juros é aplicada por período entre parcelas.
+func buildInstallmentPlan(transaction *entity.Transaction) *entity.InstallmentPlan {
+	n := transaction.TotalInstallments
+	financed := transaction.AmountCents - transaction.DownPaymentCents
+	rate := transaction.InterestRate / 100
+
+	// Valores exatos de cada parcela, antes do arredondamento
+	exact := make([]float64, n)
+	switch {
+	case rate == 0:
+		for i := range exact {
+			exact[i] = float64(financed) / float64(n)
+		}
+	case transaction.AmortizationMethod == entity.AmortizationMethodSAC:
+		amortization := float64(financed) / float64(n)
+		for i := range exact {
+			balance := float64(financed) - amortization*float64(i)
+			exact[i] = amortization + balance*rate
+		}
+	default:
+		payment := float64(financed) * rate / (1 - math.Pow(1+rate, -float64(n)))
+		for i := range exact {
+			exact[i] = payment
+		}
+	}
+
+	var exactTotal float64
+	for _, value := range exact {
+		exactTotal += value
+	}
+	total := int64(math.Round(exactTotal))
+
+	amounts := make([]int64, n)
+	var rounded int64
+	if rate == 0 || transaction.AmortizationMethod != entity.AmortizationMethodSAC {
+		// Parcelas iguais: o resto da divisão é distribuído conforme a política
+		for i := range amounts {
+			amounts[i] = total / int64(n)
+		}
+		rounded = amounts[0] * int64(n)
+	} else {
+		for i := range amounts {
+			amounts[i] = int64(math.Round(exact[i]))
+			rounded += amounts[i]
+		}
+	}
+	distributeRemainder(amounts, total-rounded, transaction.RemainderPolicy)
+
+	plan := &entity.InstallmentPlan{
+		DownPaymentCents: transaction.DownPaymentCents,
+		FinancedCents:    financed,
+		TotalCents:       transaction.DownPaymentCents + total,
+	}
+
+	if transaction.DownPaymentCents > 0 {
+		plan.Entries = append(plan.Entries, entity.InstallmentPlanEntry{
+			InstallmentNumber: 0,
+			DueDate:           transaction.DueDate,
+			AmountCents:       transaction.DownPaymentCents,
+			PrincipalCents:    transaction.DownPaymentCents,
+			BalanceCents:      financed,
+		})
+	}
+
+	dueDates := installmentDueDates(transaction)
+	balance := financed
+	for i, amount := range amounts {
+		interest := int64(math.Round(float64(balance) * rate))
+		principal := amount - interest
+		// A última parcela quita exatamente o saldo restante
+		if i == n-1 {
+			principal = balance
+			interest = amount - principal
+		}
+		balance -= principal
+
+		plan.TotalInterestCents += interest
+		plan.Entries = append(plan.Entries, entity.InstallmentPlanEntry{
+			InstallmentNumber: i + 1,
+			DueDate:           dueDates[i],
+			AmountCents:       amount,
+			PrincipalCents:    principal,
+			InterestCents:     interest,
+			BalanceCents:      balance,
+		})
+	}
+
+	return plan
+}
+
+// distributeRemainder aplica a diferença de arredondamento (positiva ou negativa) às parcelas
+func distributeRemainder(amounts []int64, remainder int64, policy entity.RemainderPolicy) {
+	if remainder == 0 || len(amounts) == 0 {
+		return
+	}
+
+	switch policy {
+	case entity.RemainderPolicyLast:
+		amounts[len(amounts)-1] += remainder
+	case entity.RemainderPolicySpread:
+		step := int64(1)
+		if remainder < 0 {
+			step = -1
+		}
+		for i := 0; remainder != 0; i++ {
+			amounts[i%len(amounts)] += step
+			remainder -= step
+		}
+	default:
+		amounts[0] += remainder
+	}
+}
@@ -0,0 +1,296 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"manager/internal/entity"
+)
+
+func TestBuildInstallmentPlan(t *testing.T) {
+	tests := []struct {
+		name        string
+		transaction entity.Transaction
+		// Valores esperados das parcelas (sem a entrada), quando conhecidos
+		wantAmounts []int64
+	}{
+		{
+			name:        "zero interest with remainder on first",
+			transaction: entity.Transaction{AmountCents: 1000, TotalInstallments: 3, RemainderPolicy: entity.RemainderPolicyFirst},
+			wantAmounts: []int64{334, 333, 333},
+		},
+		{
+			name:        "zero interest with remainder on last",
+			transaction: entity.Transaction{AmountCents: 1000, TotalInstallments: 3, RemainderPolicy: entity.RemainderPolicyLast},
+			wantAmounts: []int64{333, 333, 334},
+		},
+		{
+			name:        "zero interest with spread remainder",
+			transaction: entity.Transaction{AmountCents: 1002, TotalInstallments: 4, RemainderPolicy: entity.RemainderPolicySpread},
+			wantAmounts: []int64{251, 251, 250, 250},
+		},
+		{
+			name:        "zero interest with down payment",
+			transaction: entity.Transaction{AmountCents: 10000, DownPaymentCents: 2500, TotalInstallments: 4, RemainderPolicy: entity.RemainderPolicyFirst},
+			wantAmounts: []int64{1875, 1875, 1875, 1875},
+		},
+		{
+			name:        "price",
+			transaction: entity.Transaction{AmountCents: 100000, TotalInstallments: 12, InterestRate: 1, AmortizationMethod: entity.AmortizationMethodPrice, RemainderPolicy: entity.RemainderPolicyLast},
+		},
+		{
+			name:        "price with down payment and spread remainder",
+			transaction: entity.Transaction{AmountCents: 123457, DownPaymentCents: 20000, TotalInstallments: 7, InterestRate: 2.5, AmortizationMethod: entity.AmortizationMethodPrice, RemainderPolicy: entity.RemainderPolicySpread},
+		},
+		{
+			name:        "sac",
+			transaction: entity.Transaction{AmountCents: 120000, TotalInstallments: 12, InterestRate: 1, AmortizationMethod: entity.AmortizationMethodSAC, RemainderPolicy: entity.RemainderPolicyFirst},
+		},
+		{
+			name:        "sac with down payment",
+			transaction: entity.Transaction{AmountCents: 99999, DownPaymentCents: 9999, TotalInstallments: 7, InterestRate: 3, AmortizationMethod: entity.AmortizationMethodSAC, RemainderPolicy: entity.RemainderPolicyLast},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := tt.transaction
+			transaction.IsInstallment = true
+			transaction.DueDate = date(2025, 1, 31)
+			if transaction.AmortizationMethod == "" {
+				transaction.AmortizationMethod = entity.AmortizationMethodPrice
+			}
+
+			plan := buildInstallmentPlan(&transaction)
+
+			entries := plan.Entries
+			if transaction.DownPaymentCents > 0 {
+				if entries[0].InstallmentNumber != 0 || entries[0].AmountCents != transaction.DownPaymentCents {
+					t.Fatalf("down payment entry = %+v", entries[0])
+				}
+				entries = entries[1:]
+			}
+			if len(entries) != transaction.TotalInstallments {
+				t.Fatalf("got %d installments, want %d", len(entries), transaction.TotalInstallments)
+			}
+
+			var amounts []int64
+			var sum, principal, interest int64
+			for _, entry := range entries {
+				amounts = append(amounts, entry.AmountCents)
+				sum += entry.AmountCents
+				principal += entry.PrincipalCents
+				interest += entry.InterestCents
+			}
+
+			if tt.wantAmounts != nil && !slices.Equal(amounts, tt.wantAmounts) {
+				t.Errorf("amounts = %v, want %v", amounts, tt.wantAmounts)
+			}
+			if plan.TotalCents != transaction.DownPaymentCents+sum {
+				t.Errorf("total = %d, down payment + installments = %d", plan.TotalCents, transaction.DownPaymentCents+sum)
+			}
+			if principal != plan.FinancedCents {
+				t.Errorf("principal = %d, want financed %d", principal, plan.FinancedCents)
+			}
+			if interest != plan.TotalInterestCents || plan.TotalCents != transaction.AmountCents+interest {
+				t.Errorf("interest = %d, total interest = %d, total = %d", interest, plan.TotalInterestCents, plan.TotalCents)
+			}
+			if last := entries[len(entries)-1]; last.BalanceCents != 0 {
+				t.Errorf("final balance = %d, want 0", last.BalanceCents)
+			}
+
+			switch {
+			case transaction.InterestRate == 0:
+				if plan.TotalCents != transaction.AmountCents || plan.TotalInterestCents != 0 {
+					t.Errorf("zero interest plan total = %d, interest = %d", plan.TotalCents, plan.TotalInterestCents)
+				}
+			case transaction.AmortizationMethod == entity.AmortizationMethodSAC:
+				for i := 1; i < len(amounts); i++ {
+					if amounts[i] > amounts[i-1] {
+						t.Errorf("sac installments must not increase: %v", amounts)
+						break
+					}
+				}
+			default:
+				if spread := slices.Max(amounts) - slices.Min(amounts); spread > int64(len(amounts)) {
+					t.Errorf("price installments differ by %d: %v", spread, amounts)
+				}
+			}
+		})
+	}
+}
+
+func TestDistributeRemainder(t *testing.T) {
+	tests := []struct {
+		name      string
+		remainder int64
+		policy    entity.RemainderPolicy
+		want      []int64
+	}{
+		{"first", 2, entity.RemainderPolicyFirst, []int64{102, 100, 100}},
+		{"last", 2, entity.RemainderPolicyLast, []int64{100, 100, 102}},
+		{"spread", 4, entity.RemainderPolicySpread, []int64{102, 101, 101}},
+		{"negative spread", -2, entity.RemainderPolicySpread, []int64{99, 99, 100}},
+		{"negative last", -1, entity.RemainderPolicyLast, []int64{100, 100, 99}},
+		{"zero", 0, entity.RemainderPolicyFirst, []int64{100, 100, 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amounts := []int64{100, 100, 100}
+			distributeRemainder(amounts, tt.remainder, tt.policy)
+			if !slices.Equal(amounts, tt.want) {
+				t.Errorf("amounts = %v, want %v", amounts, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstallmentDueDates(t *testing.T) {
+	interval := 10
+
+	tests := []struct {
+		name        string
+		transaction entity.Transaction
+		want        []time.Time
+	}{
+		{
+			name:        "monthly from jan 31",
+			transaction: entity.Transaction{DueDate: date(2025, 1, 31), TotalInstallments: 4, InstallmentSchedule: entity.InstallmentScheduleMonthly},
+			want:        []time.Time{date(2025, 1, 31), date(2025, 2, 28), date(2025, 3, 31), date(2025, 4, 30)},
+		},
+		{
+			name:        "monthly from jan 31 in a leap year",
+			transaction: entity.Transaction{DueDate: date(2024, 1, 31), TotalInstallments: 2, InstallmentSchedule: entity.InstallmentScheduleMonthly},
+			want:        []time.Time{date(2024, 1, 31), date(2024, 2, 29)},
+		},
+		{
+			name:        "monthly with down payment starts a month later",
+			transaction: entity.Transaction{DueDate: date(2025, 1, 31), DownPaymentCents: 100, TotalInstallments: 2, InstallmentSchedule: entity.InstallmentScheduleMonthly},
+			want:        []time.Time{date(2025, 2, 28), date(2025, 3, 31)},
+		},
+		{
+			name:        "weekly",
+			transaction: entity.Transaction{DueDate: date(2025, 12, 24), TotalInstallments: 3, InstallmentSchedule: entity.InstallmentScheduleWeekly},
+			want:        []time.Time{date(2025, 12, 24), date(2025, 12, 31), date(2026, 1, 7)},
+		},
+		{
+			name:        "every n days with down payment",
+			transaction: entity.Transaction{DueDate: date(2025, 2, 20), DownPaymentCents: 100, TotalInstallments: 2, InstallmentSchedule: entity.InstallmentScheduleEveryNDays, InstallmentIntervalDays: &interval},
+			want:        []time.Time{date(2025, 3, 2), date(2025, 3, 12)},
+		},
+		{
+			name:        "custom",
+			transaction: entity.Transaction{DueDate: date(2025, 1, 5), TotalInstallments: 2, InstallmentSchedule: entity.InstallmentScheduleCustom, InstallmentDates: []time.Time{date(2025, 1, 5), date(2025, 3, 20)}},
+			want:        []time.Time{date(2025, 1, 5), date(2025, 3, 20)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := installmentDueDates(&tt.transaction)
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("due dates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	tests := []struct {
+		from   time.Time
+		months int
+		day    int
+		want   time.Time
+	}{
+		{date(2025, 1, 31), 1, 31, date(2025, 2, 28)},
+		{date(2024, 1, 31), 1, 31, date(2024, 2, 29)},
+		{date(2025, 1, 31), 2, 31, date(2025, 3, 31)},
+		{date(2025, 3, 31), -1, 31, date(2025, 2, 28)},
+		{date(2025, 11, 30), 3, 30, date(2026, 2, 28)},
+		{date(2025, 5, 15), 12, 15, date(2026, 5, 15)},
+	}
+
+	for _, tt := range tests {
+		if got := addMonthsClamped(tt.from, tt.months, tt.day); !got.Equal(tt.want) {
+			t.Errorf("addMonthsClamped(%s, %d, %d) = %s, want %s", tt.from.Format("2006-01-02"), tt.months, tt.day, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestPrepareInstallmentScheduleCustom(t *testing.T) {
+	tests := []struct {
+		name        string
+		dueDate     time.Time
+		downPayment int64
+		dates       []time.Time
+		wantErr     error
+		wantDueDate time.Time
+	}{
+		{
+			name:        "without down payment the due date is the first installment",
+			dueDate:     date(2025, 1, 1),
+			dates:       []time.Time{date(2025, 1, 10), date(2025, 2, 10)},
+			wantDueDate: date(2025, 1, 10),
+		},
+		{
+			name:        "down payment before the first installment",
+			dueDate:     date(2025, 1, 5),
+			downPayment: 1000,
+			dates:       []time.Time{date(2025, 1, 10), date(2025, 2, 10)},
+			wantDueDate: date(2025, 1, 5),
+		},
+		{
+			name:        "down payment on the first installment date",
+			dueDate:     date(2025, 1, 10),
+			downPayment: 1000,
+			dates:       []time.Time{date(2025, 1, 10), date(2025, 2, 10)},
+			wantDueDate: date(2025, 1, 10),
+		},
+		{
+			name:        "down payment after the first installment",
+			dueDate:     date(2025, 1, 15),
+			downPayment: 1000,
+			dates:       []time.Time{date(2025, 1, 10), date(2025, 2, 10)},
+			wantErr:     entity.ErrInvalidFilter,
+		},
+		{
+			name:        "down payment after every installment",
+			dueDate:     date(2025, 3, 1),
+			downPayment: 1000,
+			dates:       []time.Time{date(2025, 1, 10), date(2025, 2, 10)},
+			wantErr:     entity.ErrInvalidFilter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := entity.Transaction{
+				AmountCents:         10000,
+				DueDate:             tt.dueDate,
+				IsInstallment:       true,
+				TotalInstallments:   len(tt.dates),
+				InstallmentSchedule: entity.InstallmentScheduleCustom,
+				InstallmentDates:    tt.dates,
+				DownPaymentCents:    tt.downPayment,
+			}
+
+			err := prepareInstallmentSchedule(&transaction)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !transaction.DueDate.Equal(tt.wantDueDate) {
+				t.Errorf("due date = %v, want %v", transaction.DueDate, tt.wantDueDate)
+			}
+		})
+	}
+}
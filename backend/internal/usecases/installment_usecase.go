@@ -0,0 +1,232 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"manager/internal/entity"
+)
+
+type PayoffResult struct {
+	Paid          []int `json:"paid"`
+	TotalCents    int64 `json:"total_cents"`
+	DiscountCents int64 `json:"discount_cents"`
+}
+
+// UnpayInstallment reverte o pagamento de uma parcela, removendo todos os seus pagamentos
+func (u *TransactionUsecase) UnpayInstallment(ctx context.Context, transactionID int64, installmentNumber int) error {
+	if transactionID <= 0 {
+		return fmt.Errorf("invalid transaction id")
+	}
+
+	if installmentNumber < 0 {
+		return fmt.Errorf("invalid installment number")
+	}
+
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		installment, err := u.repo.GetInstallment(ctx, transactionID, installmentNumber)
+		if err != nil {
+			return err
+		}
+
+		paid, _, err := u.paymentRepo.SumByTarget(ctx, transactionID, &installment.ID)
+		if err != nil {
+			return err
+		}
+
+		if installment.Status != entity.InstallmentStatusPaid && paid == 0 {
+			return fmt.Errorf("%w: installment %d is not paid", entity.ErrConflict, installmentNumber)
+		}
+
+		if err := u.paymentRepo.DeleteByTarget(ctx, transactionID, &installment.ID); err != nil {
+			return err
+		}
+
+		if installment.Status == entity.InstallmentStatusPaid {
+			if err := u.repo.UnpayInstallment(ctx, transactionID, installmentNumber); err != nil {
+				return err
+			}
+		}
+
+		return u.repo.RefreshInstallmentStatus(ctx, transactionID)
+	})
+}
+
+// CancelInstallments cancela as parcelas ainda não pagas; as pagas são mantidas
+func (u *TransactionUsecase) CancelInstallments(ctx context.Context, transactionID int64) (int64, error) {
+	if transactionID <= 0 {
+		return 0, fmt.Errorf("invalid transaction id")
+	}
+
+	var cancelled int64
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		transaction, err := u.repo.GetByID(ctx, transactionID)
+		if err != nil {
+			return err
+		}
+
+		if !transaction.IsInstallment {
+			return fmt.Errorf("%w: transaction has no installments", entity.ErrConflict)
+		}
+
+		cancelled, err = u.repo.CancelUnpaidInstallments(ctx, transactionID)
+		if err != nil {
+			return err
+		}
+
+		return u.repo.RefreshInstallmentStatus(ctx, transactionID)
+	})
+
+	return cancelled, err
+}
+
+// RescheduleInstallment altera o vencimento e/ou o valor de uma parcela não paga
+func (u *TransactionUsecase) RescheduleInstallment(ctx context.Context, transactionID int64, installmentNumber int, dueDate *time.Time, amountCents *int64) (*entity.Installment, error) {
+	if transactionID <= 0 {
+		return nil, fmt.Errorf("invalid transaction id")
+	}
+
+	if installmentNumber < 0 {
+		return nil, fmt.Errorf("invalid installment number")
+	}
+
+	if dueDate == nil && amountCents == nil {
+		return nil, fmt.Errorf("due date or amount is required")
+	}
+
+	if amountCents != nil && *amountCents <= 0 {
+		return nil, fmt.Errorf("installment amount must be greater than zero")
+	}
+
+	var installment *entity.Installment
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		installment, err = u.repo.GetInstallment(ctx, transactionID, installmentNumber)
+		if err != nil {
+			return err
+		}
+
+		if installment.Status == entity.InstallmentStatusPaid || installment.Status == entity.InstallmentStatusCancelled {
+			return fmt.Errorf("%w: installment %d is %s", entity.ErrConflict, installmentNumber, installment.Status)
+		}
+
+		if dueDate != nil {
+			installment.DueDate = truncateDay(*dueDate)
+		}
+		if amountCents != nil {
+			installment.AmountCents = *amountCents
+		}
+		installment.Status = entity.InstallmentStatusPending
+		installment.OverdueAt = nil
+
+		if err := u.repo.UpdateInstallment(ctx, installment); err != nil {
+			return err
+		}
+
+		// O novo vencimento pode mudar a fatura da parcela
+		if dueDate != nil {
+			transaction, err := u.repo.GetByID(ctx, transactionID)
+			if err != nil {
+				return err
+			}
+			if err := u.assignStatements(ctx, transaction); err != nil {
+				return err
+			}
+		}
+
+		return u.repo.RefreshInstallmentStatus(ctx, transactionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return installment, nil
+}
+
+// PayOffInstallments quita de uma vez todas as parcelas não pagas.
+// O desconto é abatido a partir da última parcela, como na antecipação de parcelas com juros,
+// e cada parcela mantém ao menos 1 centavo em aberto.
+func (u *TransactionUsecase) PayOffInstallments(ctx context.Context, transactionID int64, discountCents int64) (*PayoffResult, error) {
+	if transactionID <= 0 {
+		return nil, fmt.Errorf("invalid transaction id")
+	}
+
+	if discountCents < 0 {
+		return nil, fmt.Errorf("%w: discount cannot be negative", entity.ErrInvalidFilter)
+	}
+
+	result := &PayoffResult{Paid: []int{}, DiscountCents: discountCents}
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		transaction, err := u.repo.GetByID(ctx, transactionID)
+		if err != nil {
+			return err
+		}
+
+		// O saldo de cada parcela já desconta os pagamentos parciais
+		payments, err := u.paymentRepo.GetByTransactionID(ctx, transactionID)
+		if err != nil {
+			return err
+		}
+		applyPayments(transaction, payments)
+
+		var unpaid []entity.Installment
+		var outstanding int64
+		for _, inst := range transaction.Installments {
+			if inst.Status == entity.InstallmentStatusPending || inst.Status == entity.InstallmentStatusOverdue {
+				unpaid = append(unpaid, inst)
+				outstanding += *inst.OutstandingCents
+			}
+		}
+
+		if len(unpaid) == 0 {
+			return fmt.Errorf("%w: there are no unpaid installments", entity.ErrConflict)
+		}
+
+		if maxDiscount := outstanding - int64(len(unpaid)); discountCents > maxDiscount {
+			return fmt.Errorf("%w: discount must leave at least 1 cent per installment (at most %d cents)", entity.ErrInvalidFilter, max(maxDiscount, 0))
+		}
+
+		for _, i := range applyPayoffDiscount(unpaid, discountCents) {
+			if err := u.repo.UpdateInstallment(ctx, &unpaid[i]); err != nil {
+				return err
+			}
+		}
+
+		paidAt := time.Now()
+		for _, inst := range unpaid {
+			settled, err := u.settleInstallment(ctx, &inst, paidAt)
+			if err != nil {
+				return err
+			}
+			result.Paid = append(result.Paid, inst.InstallmentNumber)
+			result.TotalCents += settled
+		}
+
+		return u.repo.RefreshInstallmentStatus(ctx, transactionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// applyPayoffDiscount abate o desconto das parcelas em aberto, da última para a primeira,
+// mantendo ao menos 1 centavo em aberto em cada uma. Retorna os índices das parcelas alteradas.
+func applyPayoffDiscount(unpaid []entity.Installment, discountCents int64) []int {
+	var changed []int
+	remaining := discountCents
+	for i := len(unpaid) - 1; i >= 0 && remaining > 0; i-- {
+		reduction := min(remaining, max(*unpaid[i].OutstandingCents-1, 0))
+		if reduction == 0 {
+			continue
+		}
+		unpaid[i].AmountCents -= reduction
+		*unpaid[i].OutstandingCents -= reduction
+		remaining -= reduction
+		changed = append(changed, i)
+	}
+
+	return changed
+}
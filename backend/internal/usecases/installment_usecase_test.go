@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"slices"
+	"testing"
+
+	"manager/internal/entity"
+)
+
+func TestApplyPayoffDiscount(t *testing.T) {
+	tests := []struct {
+		name            string
+		amounts         []int64
+		outstanding     []int64
+		discount        int64
+		wantAmounts     []int64
+		wantOutstanding []int64
+		wantChanged     []int
+	}{
+		{
+			name:            "discount within the last installment",
+			amounts:         []int64{1000, 1000, 1000},
+			outstanding:     []int64{1000, 1000, 1000},
+			discount:        300,
+			wantAmounts:     []int64{1000, 1000, 700},
+			wantOutstanding: []int64{1000, 1000, 700},
+			wantChanged:     []int{2},
+		},
+		{
+			name:            "discount equal to the last installment keeps 1 cent",
+			amounts:         []int64{1000, 1000, 1000},
+			outstanding:     []int64{1000, 1000, 1000},
+			discount:        1000,
+			wantAmounts:     []int64{1000, 999, 1},
+			wantOutstanding: []int64{1000, 999, 1},
+			wantChanged:     []int{2, 1},
+		},
+		{
+			name:            "partially paid installment keeps its payments",
+			amounts:         []int64{1000, 1000},
+			outstanding:     []int64{1000, 400},
+			discount:        500,
+			wantAmounts:     []int64{899, 601},
+			wantOutstanding: []int64{899, 1},
+			wantChanged:     []int{1, 0},
+		},
+		{
+			name:            "maximum discount leaves 1 cent in each",
+			amounts:         []int64{500, 500, 500},
+			outstanding:     []int64{500, 500, 500},
+			discount:        1497,
+			wantAmounts:     []int64{1, 1, 1},
+			wantOutstanding: []int64{1, 1, 1},
+			wantChanged:     []int{2, 1, 0},
+		},
+		{
+			name:            "no discount",
+			amounts:         []int64{1000},
+			outstanding:     []int64{1000},
+			discount:        0,
+			wantAmounts:     []int64{1000},
+			wantOutstanding: []int64{1000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unpaid := make([]entity.Installment, len(tt.amounts))
+			for i := range unpaid {
+				outstanding := tt.outstanding[i]
+				unpaid[i] = entity.Installment{InstallmentNumber: i + 1, AmountCents: tt.amounts[i], OutstandingCents: &outstanding}
+			}
+
+			changed := applyPayoffDiscount(unpaid, tt.discount)
+
+			if !slices.Equal(changed, tt.wantChanged) {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+
+			var discounted int64
+			for i, inst := range unpaid {
+				if inst.AmountCents != tt.wantAmounts[i] || *inst.OutstandingCents != tt.wantOutstanding[i] {
+					t.Errorf("installment %d = %d (outstanding %d), want %d (outstanding %d)",
+						inst.InstallmentNumber, inst.AmountCents, *inst.OutstandingCents, tt.wantAmounts[i], tt.wantOutstanding[i])
+				}
+				if *inst.OutstandingCents < 1 {
+					t.Errorf("installment %d settled at %d cents", inst.InstallmentNumber, *inst.OutstandingCents)
+				}
+				discounted += tt.amounts[i] - inst.AmountCents
+			}
+
+			if discounted != tt.discount {
+				t.Errorf("discounted %d cents, want %d", discounted, tt.discount)
+			}
+		})
+	}
+}
+
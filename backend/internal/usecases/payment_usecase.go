@@ -0,0 +1,274 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"manager/internal/entity"
+)
+
+// ListPayments lista os pagamentos de uma transação e de suas parcelas
+func (u *TransactionUsecase) ListPayments(ctx context.Context, transactionID int64) ([]entity.Payment, error) {
+	if transactionID <= 0 {
+		return nil, fmt.Errorf("invalid transaction id")
+	}
+
+	if _, err := u.repo.GetByID(ctx, transactionID); err != nil {
+		return nil, err
+	}
+
+	return u.paymentRepo.GetByTransactionID(ctx, transactionID)
+}
+
+// AddPayment registra um pagamento parcial ou total. Em compras parceladas o pagamento é
+// de uma parcela (installmentNumber). Ao atingir o valor devido, a parcela ou a transação
+// passa para paga.
+func (u *TransactionUsecase) AddPayment(ctx context.Context, transactionID int64, installmentNumber *int, payment *entity.Payment) (*entity.Payment, error) {
+	if transactionID <= 0 {
+		return nil, fmt.Errorf("invalid transaction id")
+	}
+
+	if payment.AmountCents <= 0 {
+		return nil, fmt.Errorf("payment amount must be greater than zero")
+	}
+
+	if payment.PaidAt.IsZero() {
+		payment.PaidAt = time.Now()
+	}
+	payment.TransactionID = transactionID
+
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		transaction, err := u.repo.GetByID(ctx, transactionID)
+		if err != nil {
+			return err
+		}
+
+		if transaction.Status == entity.TransactionStatusCancelled {
+			return fmt.Errorf("%w: transaction is cancelled", entity.ErrConflict)
+		}
+
+		if transaction.Type == entity.TransactionTypeTransfer {
+			return fmt.Errorf("%w: transfers have no payments", entity.ErrConflict)
+		}
+
+		if !transaction.IsInstallment {
+			if installmentNumber != nil {
+				return fmt.Errorf("%w: transaction has no installments", entity.ErrConflict)
+			}
+
+			if transaction.Status == entity.TransactionStatusPaid {
+				return fmt.Errorf("%w: transaction is already paid", entity.ErrConflict)
+			}
+
+			paid, _, err := u.paymentRepo.SumByTarget(ctx, transactionID, nil)
+			if err != nil {
+				return err
+			}
+
+			if outstanding := settlementTarget(transaction) - paid; payment.AmountCents > outstanding {
+				return fmt.Errorf("%w: payment exceeds the outstanding amount of %d cents", entity.ErrConflict, outstanding)
+			}
+
+			if err := u.paymentRepo.Create(ctx, payment); err != nil {
+				return err
+			}
+
+			return u.syncTransactionPayments(ctx, transaction)
+		}
+
+		if installmentNumber == nil {
+			return fmt.Errorf("installment number is required for installment transactions")
+		}
+
+		installment, err := u.repo.GetInstallment(ctx, transactionID, *installmentNumber)
+		if err != nil {
+			return err
+		}
+
+		if installment.Status == entity.InstallmentStatusPaid || installment.Status == entity.InstallmentStatusCancelled {
+			return fmt.Errorf("%w: installment %d is %s", entity.ErrConflict, installment.InstallmentNumber, installment.Status)
+		}
+
+		paid, _, err := u.paymentRepo.SumByTarget(ctx, transactionID, &installment.ID)
+		if err != nil {
+			return err
+		}
+
+		outstanding := installment.AmountCents - paid
+		if payment.AmountCents > outstanding {
+			return fmt.Errorf("%w: payment exceeds the outstanding amount of %d cents", entity.ErrConflict, outstanding)
+		}
+
+		payment.InstallmentID = &installment.ID
+		payment.InstallmentNumber = &installment.InstallmentNumber
+		if err := u.paymentRepo.Create(ctx, payment); err != nil {
+			return err
+		}
+
+		if payment.AmountCents == outstanding {
+			if err := u.repo.PayInstallment(ctx, transactionID, installment.InstallmentNumber, payment.PaidAt); err != nil {
+				return err
+			}
+		}
+
+		return u.repo.RefreshInstallmentStatus(ctx, transactionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// DeletePayment estorna um pagamento, reabrindo a parcela ou a transação se necessário
+func (u *TransactionUsecase) DeletePayment(ctx context.Context, transactionID int64, paymentID int64) error {
+	if transactionID <= 0 {
+		return fmt.Errorf("invalid transaction id")
+	}
+
+	if paymentID <= 0 {
+		return fmt.Errorf("invalid payment id")
+	}
+
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		payment, err := u.paymentRepo.GetByID(ctx, transactionID, paymentID)
+		if err != nil {
+			return err
+		}
+
+		if err := u.paymentRepo.Delete(ctx, payment.ID); err != nil {
+			return err
+		}
+
+		if payment.InstallmentNumber == nil {
+			transaction, err := u.repo.GetByID(ctx, transactionID)
+			if err != nil {
+				return err
+			}
+			return u.syncTransactionPayments(ctx, transaction)
+		}
+
+		installment, err := u.repo.GetInstallment(ctx, transactionID, *payment.InstallmentNumber)
+		if err != nil {
+			return err
+		}
+
+		if installment.Status == entity.InstallmentStatusPaid {
+			if err := u.repo.UnpayInstallment(ctx, transactionID, installment.InstallmentNumber); err != nil {
+				return err
+			}
+		}
+
+		return u.repo.RefreshInstallmentStatus(ctx, transactionID)
+	})
+}
+
+// syncTransactionPayments atualiza o status de uma transação simples a partir do total pago.
+// A transação fica paga enquanto os pagamentos cobrirem o valor combinado na quitação.
+func (u *TransactionUsecase) syncTransactionPayments(ctx context.Context, transaction *entity.Transaction) error {
+	paid, lastPaidAt, err := u.paymentRepo.SumByTarget(ctx, transaction.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case paid >= settlementTarget(transaction):
+		return u.repo.Pay(ctx, transaction.ID, *lastPaidAt, paid)
+	case paid > 0:
+		return u.repo.MarkPartiallyPaid(ctx, transaction.ID)
+	default:
+		return u.repo.Unpay(ctx, transaction.ID)
+	}
+}
+
+// settlementTarget retorna o valor que quita a transação simples: o combinado na última quitação
+// (ex: com desconto), se houver, ou o valor previsto
+func settlementTarget(transaction *entity.Transaction) int64 {
+	if transaction.SettledAmountCents != nil {
+		return *transaction.SettledAmountCents
+	}
+	return transaction.AmountCents
+}
+
+// settleTransaction quita uma transação simples: registra a diferença entre o valor pago
+// informado e os pagamentos anteriores e marca a transação como paga. Retorna o valor
+// registrado no novo pagamento.
+func (u *TransactionUsecase) settleTransaction(ctx context.Context, id int64, paidAt time.Time, amount int64) (int64, error) {
+	paid, _, err := u.paymentRepo.SumByTarget(ctx, id, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if amount < paid {
+		return 0, fmt.Errorf("%w: %d cents have already been paid", entity.ErrConflict, paid)
+	}
+
+	if amount > paid {
+		payment := &entity.Payment{TransactionID: id, AmountCents: amount - paid, PaidAt: paidAt}
+		if err := u.paymentRepo.Create(ctx, payment); err != nil {
+			return 0, err
+		}
+	}
+
+	return amount - paid, u.repo.Pay(ctx, id, paidAt, amount)
+}
+
+// settleInstallment registra o pagamento do saldo em aberto da parcela e a marca como paga.
+// Retorna o valor registrado no novo pagamento.
+func (u *TransactionUsecase) settleInstallment(ctx context.Context, installment *entity.Installment, paidAt time.Time) (int64, error) {
+	paid, _, err := u.paymentRepo.SumByTarget(ctx, installment.TransactionID, &installment.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	outstanding := max(installment.AmountCents-paid, 0)
+	if outstanding > 0 {
+		payment := &entity.Payment{
+			TransactionID: installment.TransactionID,
+			InstallmentID: &installment.ID,
+			AmountCents:   outstanding,
+			PaidAt:        paidAt,
+		}
+		if err := u.paymentRepo.Create(ctx, payment); err != nil {
+			return 0, err
+		}
+	}
+
+	return outstanding, u.repo.PayInstallment(ctx, installment.TransactionID, installment.InstallmentNumber, paidAt)
+}
+
+// applyPayments anexa os pagamentos à transação e calcula o total pago e o saldo em aberto,
+// da transação e de cada parcela. Parcelas canceladas e itens quitados não têm saldo.
+func applyPayments(transaction *entity.Transaction, payments []entity.Payment) {
+	transaction.Payments = payments
+
+	paidByInstallment := make(map[int64]int64)
+	var paid int64
+	for _, p := range payments {
+		paid += p.AmountCents
+		if p.InstallmentID != nil {
+			paidByInstallment[*p.InstallmentID] += p.AmountCents
+		}
+	}
+
+	var outstanding int64
+	if transaction.IsInstallment {
+		for i := range transaction.Installments {
+			inst := &transaction.Installments[i]
+			instPaid := paidByInstallment[inst.ID]
+			var instOutstanding int64
+			if inst.Status != entity.InstallmentStatusPaid && inst.Status != entity.InstallmentStatusCancelled {
+				instOutstanding = max(inst.AmountCents-instPaid, 0)
+			}
+			inst.PaidCents = &instPaid
+			inst.OutstandingCents = &instOutstanding
+			outstanding += instOutstanding
+		}
+	} else if transaction.Status != entity.TransactionStatusPaid && transaction.Status != entity.TransactionStatusCancelled {
+		outstanding = max(settlementTarget(transaction)-paid, 0)
+	}
+
+	transaction.PaidCents = &paid
+	transaction.OutstandingCents = &outstanding
+}
@@ -0,0 +1,324 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"manager/internal/entity"
+	"manager/internal/repositories"
+)
+
+type RecurrenceUsecase struct {
+	repo *repositories.RecurrenceRepository
+}
+
+func NewRecurrenceUsecase(repo *repositories.RecurrenceRepository) *RecurrenceUsecase {
+	return &RecurrenceUsecase{repo: repo}
+}
+
+func (u *RecurrenceUsecase) Create(ctx context.Context, recurrence *entity.Recurrence) error {
+	if recurrence.StartDate.IsZero() {
+		return fmt.Errorf("recurrence start date is required")
+	}
+
+	if err := prepareRecurrence(recurrence); err != nil {
+		return err
+	}
+
+	recurrence.OccurrencesCount = 0
+	recurrence.NextDueDate = recurrence.StartDate
+	recurrence.Status = entity.RecurrenceStatusActive
+	if !recurrenceHasNext(recurrence, recurrence.NextDueDate) {
+		return fmt.Errorf("recurrence ends before its first occurrence")
+	}
+
+	return u.repo.Create(ctx, recurrence)
+}
+
+func (u *RecurrenceUsecase) GetAll(ctx context.Context) ([]entity.Recurrence, error) {
+	return u.repo.GetAll(ctx)
+}
+
+func (u *RecurrenceUsecase) GetByID(ctx context.Context, id int64) (*entity.Recurrence, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid recurrence id")
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+// Update altera o modelo e a regra da série; só afeta as ocorrências ainda não geradas
+func (u *RecurrenceUsecase) Update(ctx context.Context, recurrence *entity.Recurrence) error {
+	if recurrence.ID <= 0 {
+		return fmt.Errorf("invalid recurrence id")
+	}
+
+	current, err := u.repo.GetByID(ctx, recurrence.ID)
+	if err != nil {
+		return err
+	}
+
+	recurrence.StartDate = current.StartDate
+	recurrence.OccurrencesCount = current.OccurrencesCount
+	recurrence.CreatedAt = current.CreatedAt
+	if recurrence.Status == "" {
+		recurrence.Status = current.Status
+	}
+	if recurrence.Status != entity.RecurrenceStatusActive && recurrence.Status != entity.RecurrenceStatusPaused {
+		recurrence.Status = current.Status
+	}
+
+	if err := prepareRecurrence(recurrence); err != nil {
+		return err
+	}
+
+	if recurrence.NextDueDate.IsZero() {
+		recurrence.NextDueDate = current.NextDueDate
+		// Realinhar a próxima ocorrência ao novo dia do mês
+		if recurrence.DayOfMonth != nil && (recurrence.Frequency == entity.RecurrenceFrequencyMonthly || recurrence.Frequency == entity.RecurrenceFrequencyYearly) {
+			recurrence.NextDueDate = addMonthsClamped(recurrence.NextDueDate, 0, *recurrence.DayOfMonth)
+		}
+	}
+
+	if !recurrenceHasNext(recurrence, recurrence.NextDueDate) {
+		recurrence.Status = entity.RecurrenceStatusFinished
+	}
+
+	return u.repo.Update(ctx, recurrence)
+}
+
+func (u *RecurrenceUsecase) Delete(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid recurrence id")
+	}
+
+	return u.repo.Delete(ctx, id)
+}
+
+func (u *RecurrenceUsecase) Pause(ctx context.Context, id int64) (*entity.Recurrence, error) {
+	recurrence, err := u.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if recurrence.Status != entity.RecurrenceStatusActive {
+		return nil, fmt.Errorf("only active recurrences can be paused")
+	}
+
+	if err := u.repo.UpdateStatus(ctx, id, entity.RecurrenceStatusPaused, recurrence.NextDueDate); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+// Resume reativa a série; ocorrências que venceram durante a pausa não são geradas
+func (u *RecurrenceUsecase) Resume(ctx context.Context, id int64) (*entity.Recurrence, error) {
+	recurrence, err := u.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if recurrence.Status != entity.RecurrenceStatusPaused {
+		return nil, fmt.Errorf("only paused recurrences can be resumed")
+	}
+
+	today := truncateDay(time.Now())
+	next := recurrence.NextDueDate
+	for next.Before(today) {
+		next = nextOccurrence(recurrence, next)
+	}
+
+	status := entity.RecurrenceStatusActive
+	if !recurrenceHasNext(recurrence, next) {
+		status = entity.RecurrenceStatusFinished
+	}
+
+	if err := u.repo.UpdateStatus(ctx, id, status, next); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+// Skip pula a próxima ocorrência da série sem gerar transação
+func (u *RecurrenceUsecase) Skip(ctx context.Context, id int64) (*entity.Recurrence, error) {
+	recurrence, err := u.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if recurrence.Status == entity.RecurrenceStatusFinished {
+		return nil, fmt.Errorf("recurrence is finished")
+	}
+
+	next := nextOccurrence(recurrence, recurrence.NextDueDate)
+	recurrence.OccurrencesCount++
+	status := recurrence.Status
+	if !recurrenceHasNext(recurrence, next) {
+		status = entity.RecurrenceStatusFinished
+	}
+
+	skipped, err := u.repo.SkipOccurrence(ctx, id, recurrence.NextDueDate, next, status)
+	if err != nil {
+		return nil, err
+	}
+	if !skipped {
+		return nil, fmt.Errorf("recurrence was modified concurrently, try again")
+	}
+
+	return u.repo.GetByID(ctx, id)
+}
+
+// GenerateDue materializa as ocorrências com vencimento até a data informada.
+// Pode ser executado repetidamente: cada ocorrência é criada no máximo uma vez.
+func (u *RecurrenceUsecase) GenerateDue(ctx context.Context, until time.Time) (int, error) {
+	until = truncateDay(until)
+	recurrences, err := u.repo.GetDue(ctx, until)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for i := range recurrences {
+		recurrence := &recurrences[i]
+
+		for !recurrence.NextDueDate.After(until) {
+			if !recurrenceHasNext(recurrence, recurrence.NextDueDate) {
+				if err := u.repo.UpdateStatus(ctx, recurrence.ID, entity.RecurrenceStatusFinished, recurrence.NextDueDate); err != nil {
+					return generated, err
+				}
+				break
+			}
+
+			dueDate := recurrence.NextDueDate
+			next := nextOccurrence(recurrence, dueDate)
+			recurrence.OccurrencesCount++
+			status := entity.RecurrenceStatusActive
+			if !recurrenceHasNext(recurrence, next) {
+				status = entity.RecurrenceStatusFinished
+			}
+
+			ok, err := u.repo.GenerateOccurrence(ctx, recurrence.ID, dueDate, next, status)
+			if err != nil {
+				return generated, fmt.Errorf("failed to generate occurrence of recurrence %d: %w", recurrence.ID, err)
+			}
+			if !ok {
+				// Outra execução já avançou esta série
+				break
+			}
+
+			generated++
+			recurrence.NextDueDate = next
+			if status == entity.RecurrenceStatusFinished {
+				break
+			}
+		}
+	}
+
+	return generated, nil
+}
+
+// prepareRecurrence valida a regra e aplica os valores padrão
+func prepareRecurrence(recurrence *entity.Recurrence) error {
+	if recurrence.Title == "" {
+		return fmt.Errorf("recurrence title is required")
+	}
+
+	if recurrence.AmountCents <= 0 {
+		return fmt.Errorf("recurrence amount must be greater than zero")
+	}
+
+	if recurrence.Type != entity.TransactionTypeIncome && recurrence.Type != entity.TransactionTypeExpense {
+		return fmt.Errorf("invalid transaction type")
+	}
+
+	if recurrence.Frequency == "" {
+		recurrence.Frequency = entity.RecurrenceFrequencyMonthly
+	}
+
+	switch recurrence.Frequency {
+	case entity.RecurrenceFrequencyDaily, entity.RecurrenceFrequencyWeekly:
+		recurrence.DayOfMonth = nil
+	case entity.RecurrenceFrequencyMonthly, entity.RecurrenceFrequencyYearly:
+		if recurrence.DayOfMonth == nil {
+			day := recurrence.StartDate.Day()
+			recurrence.DayOfMonth = &day
+		}
+		if *recurrence.DayOfMonth < 1 || *recurrence.DayOfMonth > 31 {
+			return fmt.Errorf("day of month must be between 1 and 31")
+		}
+	default:
+		return fmt.Errorf("invalid recurrence frequency")
+	}
+
+	if recurrence.Interval == 0 {
+		recurrence.Interval = 1
+	}
+	if recurrence.Interval < 0 {
+		return fmt.Errorf("recurrence interval must be greater than zero")
+	}
+
+	if recurrence.MaxOccurrences != nil && *recurrence.MaxOccurrences <= 0 {
+		return fmt.Errorf("max occurrences must be greater than zero")
+	}
+
+	if recurrence.EndDate != nil && recurrence.EndDate.Before(recurrence.StartDate) {
+		return fmt.Errorf("recurrence end date must be after start date")
+	}
+
+	return nil
+}
+
+// recurrenceHasNext indica se a ocorrência na data informada ainda pertence à série
+func recurrenceHasNext(recurrence *entity.Recurrence, dueDate time.Time) bool {
+	if recurrence.EndDate != nil && dueDate.After(*recurrence.EndDate) {
+		return false
+	}
+
+	if recurrence.MaxOccurrences != nil && recurrence.OccurrencesCount >= *recurrence.MaxOccurrences {
+		return false
+	}
+
+	return true
+}
+
+// nextOccurrence calcula a data da ocorrência seguinte à informada
+func nextOccurrence(recurrence *entity.Recurrence, from time.Time) time.Time {
+	interval := recurrence.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	day := from.Day()
+	if recurrence.DayOfMonth != nil {
+		day = *recurrence.DayOfMonth
+	}
+
+	switch recurrence.Frequency {
+	case entity.RecurrenceFrequencyDaily:
+		return from.AddDate(0, 0, interval)
+	case entity.RecurrenceFrequencyWeekly:
+		return from.AddDate(0, 0, 7*interval)
+	case entity.RecurrenceFrequencyYearly:
+		return addMonthsClamped(from, 12*interval, day)
+	default:
+		return addMonthsClamped(from, interval, day)
+	}
+}
+
+// addMonthsClamped avança a data em meses de calendário, limitando o dia ao último dia do mês
+func addMonthsClamped(from time.Time, months int, day int) time.Time {
+	firstOfMonth := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location()).AddDate(0, months, 0)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, from.Location())
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
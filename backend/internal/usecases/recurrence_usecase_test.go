@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"manager/internal/entity"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestNextOccurrence(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurrence entity.Recurrence
+		from       time.Time
+		want       time.Time
+	}{
+		{"daily", entity.Recurrence{Frequency: entity.RecurrenceFrequencyDaily, Interval: 1}, date(2025, 2, 28), date(2025, 3, 1)},
+		{"every 3 days", entity.Recurrence{Frequency: entity.RecurrenceFrequencyDaily, Interval: 3}, date(2025, 12, 30), date(2026, 1, 2)},
+		{"weekly", entity.Recurrence{Frequency: entity.RecurrenceFrequencyWeekly, Interval: 1}, date(2025, 1, 27), date(2025, 2, 3)},
+		{"biweekly", entity.Recurrence{Frequency: entity.RecurrenceFrequencyWeekly, Interval: 2}, date(2025, 1, 1), date(2025, 1, 15)},
+		{"monthly", entity.Recurrence{Frequency: entity.RecurrenceFrequencyMonthly, Interval: 1}, date(2025, 1, 15), date(2025, 2, 15)},
+		{"monthly zero interval", entity.Recurrence{Frequency: entity.RecurrenceFrequencyMonthly}, date(2025, 1, 15), date(2025, 2, 15)},
+		{"quarterly", entity.Recurrence{Frequency: entity.RecurrenceFrequencyMonthly, Interval: 3}, date(2025, 11, 10), date(2026, 2, 10)},
+		{"monthly on the 31st clamps to february", entity.Recurrence{Frequency: entity.RecurrenceFrequencyMonthly, Interval: 1}, date(2025, 1, 31), date(2025, 2, 28)},
+		{"monthly on the 31st clamps to leap february", entity.Recurrence{Frequency: entity.RecurrenceFrequencyMonthly, Interval: 1}, date(2024, 1, 31), date(2024, 2, 29)},
+		{"day of month 31 returns after february", entity.Recurrence{Frequency: entity.RecurrenceFrequencyMonthly, Interval: 1, DayOfMonth: intPtr(31)}, date(2025, 2, 28), date(2025, 3, 31)},
+		{"day of month 31 clamps to april", entity.Recurrence{Frequency: entity.RecurrenceFrequencyMonthly, Interval: 1, DayOfMonth: intPtr(31)}, date(2025, 3, 31), date(2025, 4, 30)},
+		{"yearly", entity.Recurrence{Frequency: entity.RecurrenceFrequencyYearly, Interval: 1}, date(2025, 6, 10), date(2026, 6, 10)},
+		{"yearly on leap day", entity.Recurrence{Frequency: entity.RecurrenceFrequencyYearly, Interval: 1}, date(2024, 2, 29), date(2025, 2, 28)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextOccurrence(&tt.recurrence, tt.from); !got.Equal(tt.want) {
+				t.Errorf("nextOccurrence(%s) = %s, want %s", tt.from.Format("2006-01-02"), got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestRecurrenceHasNext(t *testing.T) {
+	endDate := date(2025, 3, 31)
+
+	tests := []struct {
+		name       string
+		recurrence entity.Recurrence
+		dueDate    time.Time
+		want       bool
+	}{
+		{"no limits", entity.Recurrence{}, date(2030, 1, 1), true},
+		{"before end date", entity.Recurrence{EndDate: &endDate}, date(2025, 3, 30), true},
+		{"on end date", entity.Recurrence{EndDate: &endDate}, date(2025, 3, 31), true},
+		{"after end date", entity.Recurrence{EndDate: &endDate}, date(2025, 4, 1), false},
+		{"below max occurrences", entity.Recurrence{MaxOccurrences: intPtr(3), OccurrencesCount: 2}, date(2025, 1, 1), true},
+		{"max occurrences reached", entity.Recurrence{MaxOccurrences: intPtr(3), OccurrencesCount: 3}, date(2025, 1, 1), false},
+		{"max occurrences before end date", entity.Recurrence{EndDate: &endDate, MaxOccurrences: intPtr(1), OccurrencesCount: 1}, date(2025, 1, 1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recurrenceHasNext(&tt.recurrence, tt.dueDate); got != tt.want {
+				t.Errorf("recurrenceHasNext = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectRecurrence(t *testing.T) {
+	endDate := date(2025, 4, 15)
+
+	tests := []struct {
+		name       string
+		recurrence entity.Recurrence
+		from       time.Time
+		until      time.Time
+		want       []time.Time
+	}{
+		{
+			name:       "monthly on the 31st",
+			recurrence: entity.Recurrence{Frequency: entity.RecurrenceFrequencyMonthly, Interval: 1, DayOfMonth: intPtr(31), NextDueDate: date(2025, 1, 31), Status: entity.RecurrenceStatusActive},
+			from:       date(2025, 1, 1),
+			until:      date(2025, 4, 30),
+			want:       []time.Time{date(2025, 1, 31), date(2025, 2, 28), date(2025, 3, 31), date(2025, 4, 30)},
+		},
+		{
+			name:       "stops at max occurrences",
+			recurrence: entity.Recurrence{Frequency: entity.RecurrenceFrequencyWeekly, Interval: 1, MaxOccurrences: intPtr(4), OccurrencesCount: 2, NextDueDate: date(2025, 1, 6), Status: entity.RecurrenceStatusActive},
+			from:       date(2025, 1, 1),
+			until:      date(2025, 3, 1),
+			want:       []time.Time{date(2025, 1, 6), date(2025, 1, 13)},
+		},
+		{
+			name:       "stops at end date",
+			recurrence: entity.Recurrence{Frequency: entity.RecurrenceFrequencyMonthly, Interval: 1, EndDate: &endDate, NextDueDate: date(2025, 2, 15), Status: entity.RecurrenceStatusActive},
+			from:       date(2025, 1, 1),
+			until:      date(2025, 12, 31),
+			want:       []time.Time{date(2025, 2, 15), date(2025, 3, 15), date(2025, 4, 15)},
+		},
+		{
+			name:       "occurrences before from count but are not listed",
+			recurrence: entity.Recurrence{Frequency: entity.RecurrenceFrequencyDaily, Interval: 1, MaxOccurrences: intPtr(3), NextDueDate: date(2025, 1, 1), Status: entity.RecurrenceStatusActive},
+			from:       date(2025, 1, 2),
+			until:      date(2025, 1, 10),
+			want:       []time.Time{date(2025, 1, 2), date(2025, 1, 3)},
+		},
+		{
+			name:       "paused series",
+			recurrence: entity.Recurrence{Frequency: entity.RecurrenceFrequencyDaily, Interval: 1, NextDueDate: date(2025, 1, 1), Status: entity.RecurrenceStatusPaused},
+			from:       date(2025, 1, 1),
+			until:      date(2025, 1, 10),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := projectRecurrence(tt.recurrence, tt.from, tt.until)
+			if len(items) != len(tt.want) {
+				t.Fatalf("got %d occurrences, want %d", len(items), len(tt.want))
+			}
+			for i, item := range items {
+				if !item.DueDate.Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %s, want %s", i, item.DueDate.Format("2006-01-02"), tt.want[i].Format("2006-01-02"))
+				}
+			}
+		})
+	}
+}
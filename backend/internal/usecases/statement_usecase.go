@@ -0,0 +1,239 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"manager/internal/entity"
+)
+
+// ListStatements lista as faturas de um cartão de crédito
+func (u *TransactionUsecase) ListStatements(ctx context.Context, accountID int64) ([]entity.Statement, error) {
+	if _, err := u.creditCard(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	return u.statementRepo.GetByAccount(ctx, accountID)
+}
+
+// GetStatement retorna a fatura com seus lançamentos
+func (u *TransactionUsecase) GetStatement(ctx context.Context, accountID int64, statementID int64) (*entity.Statement, error) {
+	if _, err := u.creditCard(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	statement, err := u.statementRepo.GetByID(ctx, accountID, statementID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement.Items, err = u.statementRepo.GetItems(ctx, statement.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return statement, nil
+}
+
+// PayStatement quita todos os lançamentos em aberto da fatura. Se fromAccountID for informado,
+// registra uma transferência dessa conta para o cartão com o valor em aberto, isto é, a soma
+// do que faltava pagar em cada lançamento (descontados os pagamentos parciais).
+func (u *TransactionUsecase) PayStatement(ctx context.Context, accountID int64, statementID int64, fromAccountID *int64, paidAt *time.Time) (*entity.Statement, error) {
+	date := time.Now()
+	if paidAt != nil {
+		date = *paidAt
+	}
+
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		card, err := u.creditCard(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		statement, err := u.statementRepo.GetByID(ctx, accountID, statementID)
+		if err != nil {
+			return err
+		}
+
+		if statement.Status == entity.StatementStatusPaid {
+			return fmt.Errorf("%w: statement is already paid", entity.ErrConflict)
+		}
+
+		items, err := u.statementRepo.GetItems(ctx, statement.ID)
+		if err != nil {
+			return err
+		}
+
+		var installmentTransactionIDs []int64
+		var outstanding int64
+		for _, item := range items {
+			if item.Status == string(entity.TransactionStatusPaid) {
+				continue
+			}
+
+			var settled int64
+			if item.InstallmentNumber == nil {
+				transaction, err := u.repo.GetByID(ctx, item.TransactionID)
+				if err != nil {
+					return err
+				}
+				if settled, err = u.settleTransaction(ctx, transaction.ID, date, transaction.AmountCents); err != nil {
+					return err
+				}
+			} else {
+				installment, err := u.repo.GetInstallment(ctx, item.TransactionID, *item.InstallmentNumber)
+				if err != nil {
+					return err
+				}
+				if settled, err = u.settleInstallment(ctx, installment, date); err != nil {
+					return err
+				}
+				installmentTransactionIDs = append(installmentTransactionIDs, item.TransactionID)
+			}
+
+			// Estornos (receitas no cartão) abatem o valor a pagar
+			if item.Type == entity.TransactionTypeIncome {
+				settled = -settled
+			}
+			outstanding += settled
+		}
+
+		if len(installmentTransactionIDs) > 0 {
+			if err := u.repo.RefreshInstallmentStatus(ctx, installmentTransactionIDs...); err != nil {
+				return err
+			}
+		}
+
+		var paymentTransactionID *int64
+		if fromAccountID != nil && outstanding > 0 {
+			transfer := &entity.Transaction{
+				Title:                fmt.Sprintf("Pagamento da fatura %s %s", card.Name, statement.DueDate.Format("01/2006")),
+				AmountCents:          outstanding,
+				Type:                 entity.TransactionTypeTransfer,
+				AccountID:            fromAccountID,
+				DestinationAccountID: &card.ID,
+				DueDate:              truncateDay(date),
+				TotalInstallments:    1,
+				Status:               entity.TransactionStatusPaid,
+			}
+
+			if err := u.checkAccounts(ctx, transfer, nil); err != nil {
+				return err
+			}
+
+			if err := u.repo.Create(ctx, transfer); err != nil {
+				return err
+			}
+			paymentTransactionID = &transfer.ID
+		}
+
+		return u.statementRepo.MarkPaid(ctx, statement.ID, date, paymentTransactionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetStatement(ctx, accountID, statementID)
+}
+
+// creditCard busca a conta e garante que é um cartão de crédito com fechamento configurado
+func (u *TransactionUsecase) creditCard(ctx context.Context, accountID int64) (*entity.Account, error) {
+	if accountID <= 0 {
+		return nil, fmt.Errorf("invalid account id")
+	}
+
+	account, err := u.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Type != entity.AccountTypeCreditCard || account.ClosingDay == nil || account.DueDay == nil {
+		return nil, fmt.Errorf("%w: account %d is not a credit card", entity.ErrConflict, accountID)
+	}
+
+	return account, nil
+}
+
+// assignStatements lança a compra (ou cada parcela) na fatura do cartão correspondente à sua
+// data. Transações fora de cartões de crédito e transferências não entram em faturas.
+func (u *TransactionUsecase) assignStatements(ctx context.Context, transaction *entity.Transaction) error {
+	if err := u.statementRepo.ClearTransaction(ctx, transaction.ID); err != nil {
+		return err
+	}
+
+	if transaction.AccountID == nil || transaction.Type == entity.TransactionTypeTransfer {
+		return nil
+	}
+
+	account, err := u.accountRepo.GetByID(ctx, *transaction.AccountID)
+	if err != nil {
+		return err
+	}
+
+	if account.Type != entity.AccountTypeCreditCard || account.ClosingDay == nil || account.DueDay == nil {
+		return nil
+	}
+
+	if !transaction.IsInstallment {
+		settled := transaction.Status == entity.TransactionStatusPaid || transaction.Status == entity.TransactionStatusCancelled
+		statementID, err := u.statementFor(ctx, account, transaction.DueDate, settled)
+		if err != nil {
+			return err
+		}
+		return u.statementRepo.AssignTransaction(ctx, transaction.ID, statementID)
+	}
+
+	installments, err := u.repo.GetInstallmentsByTransactionID(ctx, transaction.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, inst := range installments {
+		settled := inst.Status == entity.InstallmentStatusPaid || inst.Status == entity.InstallmentStatusCancelled
+		statementID, err := u.statementFor(ctx, account, inst.DueDate, settled)
+		if err != nil {
+			return err
+		}
+		if err := u.statementRepo.AssignInstallment(ctx, inst.ID, statementID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// statementFor retorna a fatura do cartão em que cai um lançamento na data informada. Uma fatura
+// já paga só recebe lançamentos quitados ou cancelados (ex: ao editar uma compra antiga).
+func (u *TransactionUsecase) statementFor(ctx context.Context, account *entity.Account, date time.Time, settled bool) (int64, error) {
+	closingDate, dueDate := statementDates(account, date)
+	statementID, paid, err := u.statementRepo.GetOrCreate(ctx, account.ID, closingDate, dueDate)
+	if err != nil {
+		return 0, err
+	}
+
+	if paid && !settled {
+		return 0, fmt.Errorf("%w: the statement closing on %s is already paid", entity.ErrConflict, closingDate.Format(time.DateOnly))
+	}
+
+	return statementID, nil
+}
+
+// statementDates retorna o fechamento e o vencimento da fatura em que cai um lançamento na data
+// informada. Lançamentos a partir do dia de fechamento entram na fatura seguinte; o vencimento
+// fica no mesmo mês do fechamento se o dia de vencimento for posterior, senão no mês seguinte.
+func statementDates(account *entity.Account, date time.Time) (time.Time, time.Time) {
+	date = truncateDay(date)
+
+	closingDate := addMonthsClamped(date, 0, *account.ClosingDay)
+	if !date.Before(closingDate) {
+		closingDate = addMonthsClamped(date, 1, *account.ClosingDay)
+	}
+
+	dueMonths := 0
+	if *account.DueDay <= *account.ClosingDay {
+		dueMonths = 1
+	}
+
+	return closingDate, addMonthsClamped(closingDate, dueMonths, *account.DueDay)
+}
@@ -3,17 +3,30 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"manager/internal/entity"
 	"manager/internal/repositories"
 )
 
 type TransactionUsecase struct {
-	repo *repositories.TransactionRepository
+	uow            *repositories.UnitOfWork
+	repo           *repositories.TransactionRepository
+	recurrenceRepo *repositories.RecurrenceRepository
+	paymentRepo    *repositories.PaymentRepository
+	accountRepo    *repositories.AccountRepository
+	statementRepo  *repositories.StatementRepository
 }
 
-func NewTransactionUsecase(repo *repositories.TransactionRepository) *TransactionUsecase {
-	return &TransactionUsecase{repo: repo}
+func NewTransactionUsecase(uow *repositories.UnitOfWork, repo *repositories.TransactionRepository, recurrenceRepo *repositories.RecurrenceRepository, paymentRepo *repositories.PaymentRepository, accountRepo *repositories.AccountRepository, statementRepo *repositories.StatementRepository) *TransactionUsecase {
+	return &TransactionUsecase{
+		uow:            uow,
+		repo:           repo,
+		recurrenceRepo: recurrenceRepo,
+		paymentRepo:    paymentRepo,
+		accountRepo:    accountRepo,
+		statementRepo:  statementRepo,
+	}
 }
 
 func (u *TransactionUsecase) Create(ctx context.Context, transaction *entity.Transaction) error {
@@ -25,8 +38,8 @@ func (u *TransactionUsecase) Create(ctx context.Context, transaction *entity.Tra
 		return fmt.Errorf("transaction amount must be greater than zero")
 	}
 
-	if transaction.Type != entity.TransactionTypeIncome && transaction.Type != entity.TransactionTypeExpense {
-		return fmt.Errorf("invalid transaction type")
+	if err := validateTransactionType(transaction); err != nil {
+		return err
 	}
 
 	if transaction.Status == "" {
@@ -37,59 +50,250 @@ func (u *TransactionUsecase) Create(ctx context.Context, transaction *entity.Tra
 		if transaction.TotalInstallments <= 1 {
 			return fmt.Errorf("installment transactions must have more than 1 installment")
 		}
+		// O status da compra parcelada é derivado das parcelas, todas pendentes na criação
+		transaction.Status = entity.TransactionStatusPending
 	} else {
 		transaction.TotalInstallments = 1
 	}
 
-	// Criar transação
-	if err := u.repo.Create(ctx, transaction); err != nil {
+	if transaction.IsRecurring && transaction.IsInstallment {
+		return fmt.Errorf("installment transactions cannot be recurring")
+	}
+
+	if err := prepareInstallmentSchedule(transaction); err != nil {
 		return err
 	}
 
-	// Se for parcelada, criar as parcelas
-	if transaction.IsInstallment {
-		if err := u.createInstallments(ctx, transaction); err != nil {
+	// Série, transação e parcelas são gravadas atomicamente
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		if err := u.checkAccounts(ctx, transaction, nil); err != nil {
+			return err
+		}
+
+		// Se for recorrente, criar a série; esta transação é a primeira ocorrência
+		if transaction.IsRecurring {
+			if err := u.createRecurrence(ctx, transaction); err != nil {
+				return err
+			}
+		}
+
+		// Criar transação
+		if err := u.repo.Create(ctx, transaction); err != nil {
 			return err
 		}
+
+		// Se for parcelada, criar as parcelas
+		if transaction.IsInstallment {
+			if err := u.createInstallments(ctx, transaction); err != nil {
+				return err
+			}
+		}
+
+		// Compras no cartão de crédito entram na fatura correspondente
+		return u.assignStatements(ctx, transaction)
+	})
+}
+
+// validateTransactionType valida o tipo da transação e as regras das transferências
+func validateTransactionType(transaction *entity.Transaction) error {
+	switch transaction.Type {
+	case entity.TransactionTypeIncome, entity.TransactionTypeExpense:
+		if transaction.DestinationAccountID != nil {
+			return fmt.Errorf("destination account is only allowed for transfers")
+		}
+	case entity.TransactionTypeTransfer:
+		if transaction.AccountID == nil || transaction.DestinationAccountID == nil {
+			return fmt.Errorf("transfers require source and destination accounts")
+		}
+		if *transaction.AccountID == *transaction.DestinationAccountID {
+			return fmt.Errorf("transfer source and destination accounts must be different")
+		}
+		if transaction.IsInstallment || transaction.IsRecurring {
+			return fmt.Errorf("transfers cannot be installments or recurring")
+		}
+	default:
+		return fmt.Errorf("invalid transaction type")
 	}
 
 	return nil
 }
 
-func (u *TransactionUsecase) createInstallments(ctx context.Context, transaction *entity.Transaction) error {
-	// Calcular valor por parcela
-	amountPerInstallment := transaction.AmountCents / int64(transaction.TotalInstallments)
-	remainder := transaction.AmountCents % int64(transaction.TotalInstallments)
+// checkAccounts garante que as contas da transação existem e não estão arquivadas e que as
+// contas de uma transferência usam a mesma moeda. Em atualizações (current não nulo), as
+// contas atuais são aceitas mesmo arquivadas, para permitir editar outros campos.
+func (u *TransactionUsecase) checkAccounts(ctx context.Context, transaction *entity.Transaction, current *entity.Transaction) error {
+	var currentAccountID, currentDestinationID *int64
+	if current != nil {
+		currentAccountID, currentDestinationID = current.AccountID, current.DestinationAccountID
+	}
 
-	// Criar cada parcela com intervalo de 30 dias
-	for i := 1; i <= transaction.TotalInstallments; i++ {
-		installmentAmount := amountPerInstallment
-		// Adicionar resto na primeira parcela
-		if i == 1 {
-			installmentAmount += remainder
-		}
+	source, err := u.checkAccount(ctx, transaction.AccountID, currentAccountID)
+	if err != nil {
+		return err
+	}
 
-		// Calcular data: data inicial + (30 dias × (número da parcela - 1))
-		dueDate := transaction.DueDate.AddDate(0, 0, 30*(i-1))
+	destination, err := u.checkAccount(ctx, transaction.DestinationAccountID, currentDestinationID)
+	if err != nil {
+		return err
+	}
+
+	if source != nil && destination != nil && source.Currency != destination.Currency {
+		return fmt.Errorf("transfer accounts must use the same currency")
+	}
+
+	return nil
+}
+
+func (u *TransactionUsecase) checkAccount(ctx context.Context, accountID *int64, currentAccountID *int64) (*entity.Account, error) {
+	if accountID == nil {
+		return nil, nil
+	}
 
+	account, err := u.accountRepo.GetByID(ctx, *accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Archived && (currentAccountID == nil || *currentAccountID != account.ID) {
+		return nil, fmt.Errorf("%w: account %d is archived", entity.ErrConflict, account.ID)
+	}
+
+	return account, nil
+}
+
+func (u *TransactionUsecase) createRecurrence(ctx context.Context, transaction *entity.Transaction) error {
+	recurrence := &entity.Recurrence{}
+	if transaction.Recurrence != nil {
+		*recurrence = *transaction.Recurrence
+	}
+
+	recurrence.Title = transaction.Title
+	recurrence.Description = transaction.Description
+	recurrence.AmountCents = transaction.AmountCents
+	recurrence.Type = transaction.Type
+	recurrence.CategoryID = transaction.CategoryID
+	recurrence.StartDate = transaction.DueDate
+
+	if err := prepareRecurrence(recurrence); err != nil {
+		return err
+	}
+
+	recurrence.OccurrencesCount = 1
+	recurrence.NextDueDate = nextOccurrence(recurrence, transaction.DueDate)
+	recurrence.Status = entity.RecurrenceStatusActive
+	if !recurrenceHasNext(recurrence, recurrence.NextDueDate) {
+		recurrence.Status = entity.RecurrenceStatusFinished
+	}
+
+	if err := u.recurrenceRepo.Create(ctx, recurrence); err != nil {
+		return err
+	}
+
+	transaction.RecurrenceID = &recurrence.ID
+	transaction.Recurrence = recurrence
+
+	return nil
+}
+
+func (u *TransactionUsecase) createInstallments(ctx context.Context, transaction *entity.Transaction) error {
+	// Cronograma com entrada, juros e distribuição do arredondamento
+	plan := buildInstallmentPlan(transaction)
+
+	for _, entry := range plan.Entries {
 		installment := &entity.Installment{
 			TransactionID:     transaction.ID,
-			InstallmentNumber: i,
-			AmountCents:       installmentAmount,
-			DueDate:           dueDate,
+			InstallmentNumber: entry.InstallmentNumber,
+			AmountCents:       entry.AmountCents,
+			DueDate:           entry.DueDate,
 			Status:            entity.InstallmentStatusPending,
 		}
 
 		if err := u.repo.CreateInstallment(ctx, installment); err != nil {
-			return fmt.Errorf("failed to create installment %d: %w", i, err)
+			return fmt.Errorf("failed to create installment %d: %w", entry.InstallmentNumber, err)
 		}
 	}
 
 	return nil
 }
 
-func (u *TransactionUsecase) GetAll(ctx context.Context) ([]entity.Transaction, error) {
-	return u.repo.GetAll(ctx)
+// PreviewInstallments calcula o cronograma de parcelas sem gravar a transação
+func (u *TransactionUsecase) PreviewInstallments(transaction *entity.Transaction) (*entity.InstallmentPlan, error) {
+	if transaction.AmountCents <= 0 {
+		return nil, fmt.Errorf("transaction amount must be greater than zero")
+	}
+
+	if transaction.TotalInstallments <= 1 {
+		return nil, fmt.Errorf("installment transactions must have more than 1 installment")
+	}
+
+	if transaction.DueDate.IsZero() && transaction.InstallmentSchedule != entity.InstallmentScheduleCustom {
+		return nil, fmt.Errorf("transaction due date is required")
+	}
+
+	transaction.IsInstallment = true
+	if err := prepareInstallmentSchedule(transaction); err != nil {
+		return nil, err
+	}
+
+	return buildInstallmentPlan(transaction), nil
+}
+
+const (
+	defaultTransactionPageSize = 50
+	maxTransactionPageSize     = 500
+)
+
+func (u *TransactionUsecase) GetAll(ctx context.Context, filter entity.TransactionFilter) (*entity.TransactionPage, error) {
+	if filter.SortBy == "" {
+		filter.SortBy = entity.TransactionSortDueDate
+	}
+
+	switch filter.SortBy {
+	case entity.TransactionSortDueDate, entity.TransactionSortAmount, entity.TransactionSortCreatedAt, entity.TransactionSortTitle:
+	default:
+		return nil, fmt.Errorf("%w: invalid sort field %s", entity.ErrInvalidFilter, filter.SortBy)
+	}
+
+	if filter.SortDirection == "" {
+		filter.SortDirection = entity.SortDesc
+	}
+
+	if filter.SortDirection != entity.SortAsc && filter.SortDirection != entity.SortDesc {
+		return nil, fmt.Errorf("%w: invalid sort direction %s", entity.ErrInvalidFilter, filter.SortDirection)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTransactionPageSize
+	}
+
+	if filter.Limit > maxTransactionPageSize {
+		filter.Limit = maxTransactionPageSize
+	}
+
+	for _, t := range filter.Types {
+		if t != entity.TransactionTypeIncome && t != entity.TransactionTypeExpense && t != entity.TransactionTypeTransfer {
+			return nil, fmt.Errorf("%w: invalid transaction type %s", entity.ErrInvalidFilter, t)
+		}
+	}
+
+	for _, s := range filter.Statuses {
+		switch s {
+		case entity.TransactionStatusPending, entity.TransactionStatusPaid, entity.TransactionStatusPartiallyPaid,
+			entity.TransactionStatusOverdue, entity.TransactionStatusCancelled:
+		default:
+			return nil, fmt.Errorf("%w: invalid transaction status %s", entity.ErrInvalidFilter, s)
+		}
+	}
+
+	if filter.DueFrom != nil && filter.DueTo != nil && filter.DueTo.Before(*filter.DueFrom) {
+		return nil, fmt.Errorf("%w: due date range is invalid", entity.ErrInvalidFilter)
+	}
+
+	if filter.MinAmountCents != nil && filter.MaxAmountCents != nil && *filter.MaxAmountCents < *filter.MinAmountCents {
+		return nil, fmt.Errorf("%w: amount range is invalid", entity.ErrInvalidFilter)
+	}
+
+	return u.repo.GetAll(ctx, filter)
 }
 
 func (u *TransactionUsecase) GetByID(ctx context.Context, id int64) (*entity.Transaction, error) {
@@ -97,10 +301,34 @@ func (u *TransactionUsecase) GetByID(ctx context.Context, id int64) (*entity.Tra
 		return nil, fmt.Errorf("invalid transaction id")
 	}
 
-	return u.repo.GetByID(ctx, id)
+	transaction, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Anexar a série de recorrência, se houver
+	if transaction.RecurrenceID != nil {
+		recurrence, err := u.recurrenceRepo.GetByID(ctx, *transaction.RecurrenceID)
+		if err != nil {
+			return nil, err
+		}
+		transaction.Recurrence = recurrence
+	}
+
+	// Anexar os pagamentos e calcular o saldo em aberto
+	payments, err := u.paymentRepo.GetByTransactionID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	applyPayments(transaction, payments)
+
+	return transaction, nil
 }
 
-func (u *TransactionUsecase) Update(ctx context.Context, transaction *entity.Transaction) error {
+// Update grava a transação e reconcilia o cronograma de parcelas com os novos valores.
+// Parcelas pagas são preservadas; a diferença é redistribuída entre as parcelas não pagas.
+// fields indica os campos enviados pelo cliente; os demais mantêm os valores atuais.
+func (u *TransactionUsecase) Update(ctx context.Context, transaction *entity.Transaction, fields entity.FieldSet) error {
 	if transaction.ID <= 0 {
 		return fmt.Errorf("invalid transaction id")
 	}
@@ -113,7 +341,313 @@ func (u *TransactionUsecase) Update(ctx context.Context, transaction *entity.Tra
 		return fmt.Errorf("transaction amount must be greater than zero")
 	}
 
-	return u.repo.Update(ctx, transaction)
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		current, err := u.repo.GetByID(ctx, transaction.ID)
+		if err != nil {
+			return err
+		}
+
+		mergeTransactionUpdate(current, transaction, fields)
+
+		if err := validateTransactionType(transaction); err != nil {
+			return err
+		}
+
+		if err := u.checkAccounts(ctx, transaction, current); err != nil {
+			return err
+		}
+
+		if transaction.IsInstallment {
+			if transaction.TotalInstallments <= 1 {
+				return fmt.Errorf("installment transactions must have more than 1 installment")
+			}
+		} else {
+			transaction.TotalInstallments = 1
+		}
+
+		if transaction.IsRecurring && transaction.IsInstallment {
+			return fmt.Errorf("installment transactions cannot be recurring")
+		}
+
+		if err := checkRecurringUpdate(current, transaction); err != nil {
+			return err
+		}
+
+		if err := prepareInstallmentSchedule(transaction); err != nil {
+			return err
+		}
+
+		// Em compras parceladas só o cancelamento é informado; os demais status são derivados
+		if transaction.IsInstallment && transaction.Status != entity.TransactionStatusCancelled {
+			transaction.Status = entity.TransactionStatusPending
+		}
+
+		if err := u.repo.Update(ctx, transaction); err != nil {
+			return err
+		}
+
+		// Ajustes manuais nas parcelas só são sobrescritos se as condições mudarem
+		if installmentTermsChanged(current, transaction) {
+			changes, err := u.reconcileInstallments(ctx, transaction, current.Installments)
+			if err != nil {
+				return err
+			}
+			transaction.InstallmentChanges = changes
+		}
+
+		if err := u.assignStatements(ctx, transaction); err != nil {
+			return err
+		}
+
+		if !transaction.IsInstallment {
+			return nil
+		}
+
+		return u.repo.RefreshInstallmentStatus(ctx, transaction.ID)
+	})
+}
+
+// checkRecurringUpdate impede que is_recurring fique diferente da série da transação: séries são
+// criadas com a transação ou em POST /recurrences e encerradas pelos endpoints de recorrências
+func checkRecurringUpdate(current *entity.Transaction, transaction *entity.Transaction) error {
+	if transaction.IsRecurring == current.IsRecurring {
+		return nil
+	}
+
+	if transaction.IsRecurring {
+		return fmt.Errorf("%w: is_recurring cannot be enabled on update; create the series with POST /recurrences", entity.ErrInvalidFilter)
+	}
+
+	return fmt.Errorf("%w: is_recurring cannot be disabled on update; manage the series through /recurrences", entity.ErrInvalidFilter)
+}
+
+// mergeTransactionUpdate completa a atualização com os valores atuais dos campos não informados.
+// As condições de parcelamento são mescladas campo a campo: apenas as enviadas são alteradas.
+func mergeTransactionUpdate(current *entity.Transaction, transaction *entity.Transaction, fields entity.FieldSet) {
+	if transaction.Type == "" {
+		transaction.Type = current.Type
+	}
+
+	if transaction.Status == "" {
+		transaction.Status = current.Status
+	}
+
+	if transaction.DueDate.IsZero() {
+		transaction.DueDate = current.DueDate
+	}
+
+	if !fields.Has("is_recurring") {
+		transaction.IsRecurring = current.IsRecurring
+	}
+
+	// As contas só são removidas com o campo enviado explicitamente como null
+	if !fields.Has("account_id") {
+		transaction.AccountID = current.AccountID
+	}
+
+	if !fields.Has("destination_account_id") {
+		transaction.DestinationAccountID = current.DestinationAccountID
+	}
+
+	if transaction.IsInstallment && current.IsInstallment {
+		if !fields.Has("installment_schedule") {
+			transaction.InstallmentSchedule = current.InstallmentSchedule
+		}
+		if !fields.Has("installment_interval_days") {
+			transaction.InstallmentIntervalDays = current.InstallmentIntervalDays
+		}
+		if !fields.Has("interest_rate") {
+			transaction.InterestRate = current.InterestRate
+		}
+		if !fields.Has("amortization_method") {
+			transaction.AmortizationMethod = current.AmortizationMethod
+		}
+		if !fields.Has("down_payment_cents") {
+			transaction.DownPaymentCents = current.DownPaymentCents
+		}
+		if !fields.Has("remainder_policy") {
+			transaction.RemainderPolicy = current.RemainderPolicy
+		}
+
+		// O agendamento custom é reconstruído a partir dos vencimentos atuais
+		if transaction.InstallmentSchedule == entity.InstallmentScheduleCustom &&
+			current.InstallmentSchedule == entity.InstallmentScheduleCustom &&
+			!fields.Has("installment_dates") {
+			for _, inst := range current.Installments {
+				if inst.InstallmentNumber > 0 {
+					transaction.InstallmentDates = append(transaction.InstallmentDates, inst.DueDate)
+				}
+			}
+		}
+	}
+}
+
+// installmentTermsChanged indica se algum campo que define o cronograma de parcelas mudou
+func installmentTermsChanged(current *entity.Transaction, transaction *entity.Transaction) bool {
+	if current.IsInstallment != transaction.IsInstallment {
+		return true
+	}
+
+	if !transaction.IsInstallment {
+		return false
+	}
+
+	if current.AmountCents != transaction.AmountCents ||
+		current.TotalInstallments != transaction.TotalInstallments ||
+		!current.DueDate.Equal(transaction.DueDate) ||
+		current.InstallmentSchedule != transaction.InstallmentSchedule ||
+		current.InterestRate != transaction.InterestRate ||
+		current.AmortizationMethod != transaction.AmortizationMethod ||
+		current.DownPaymentCents != transaction.DownPaymentCents ||
+		current.RemainderPolicy != transaction.RemainderPolicy {
+		return true
+	}
+
+	if (current.InstallmentIntervalDays == nil) != (transaction.InstallmentIntervalDays == nil) ||
+		(current.InstallmentIntervalDays != nil && *current.InstallmentIntervalDays != *transaction.InstallmentIntervalDays) {
+		return true
+	}
+
+	if transaction.InstallmentSchedule == entity.InstallmentScheduleCustom {
+		i := 0
+		for _, inst := range current.Installments {
+			if inst.InstallmentNumber == 0 {
+				continue
+			}
+			if i >= len(transaction.InstallmentDates) || !inst.DueDate.Equal(transaction.InstallmentDates[i]) {
+				return true
+			}
+			i++
+		}
+		return i != len(transaction.InstallmentDates)
+	}
+
+	return false
+}
+
+// reconcileInstallments aplica o novo cronograma às parcelas existentes
+func (u *TransactionUsecase) reconcileInstallments(ctx context.Context, transaction *entity.Transaction, existing []entity.Installment) (*entity.InstallmentChanges, error) {
+	changes := &entity.InstallmentChanges{
+		Created:   []int{},
+		Updated:   []int{},
+		Removed:   []int{},
+		Preserved: []int{},
+	}
+
+	// Deixou de ser parcelada: remover as parcelas, desde que nenhuma esteja paga
+	if !transaction.IsInstallment {
+		for _, inst := range existing {
+			if inst.Status == entity.InstallmentStatusPaid {
+				return nil, fmt.Errorf("%w: installment %d is already paid", entity.ErrConflict, inst.InstallmentNumber)
+			}
+		}
+		for _, inst := range existing {
+			if err := u.repo.DeleteInstallment(ctx, transaction.ID, inst.InstallmentNumber); err != nil {
+				return nil, err
+			}
+			changes.Removed = append(changes.Removed, inst.InstallmentNumber)
+		}
+		return changes, nil
+	}
+
+	entries := buildInstallmentPlan(transaction).Entries
+	planned := make(map[int]int, len(entries))
+	for i, entry := range entries {
+		planned[entry.InstallmentNumber] = i
+	}
+
+	existingByNumber := make(map[int]entity.Installment, len(existing))
+	paid := make(map[int]bool)
+	var delta int64
+	for _, inst := range existing {
+		existingByNumber[inst.InstallmentNumber] = inst
+		if inst.Status != entity.InstallmentStatusPaid {
+			continue
+		}
+
+		idx, ok := planned[inst.InstallmentNumber]
+		if !ok {
+			return nil, fmt.Errorf("%w: installment %d is already paid and would be removed", entity.ErrConflict, inst.InstallmentNumber)
+		}
+
+		entry := entries[idx]
+		if entry.AmountCents != inst.AmountCents || !entry.DueDate.Equal(inst.DueDate) {
+			changes.Warnings = append(changes.Warnings, fmt.Sprintf("installment %d is already paid and was kept unchanged", inst.InstallmentNumber))
+		}
+
+		delta += entry.AmountCents - inst.AmountCents
+		paid[inst.InstallmentNumber] = true
+		changes.Preserved = append(changes.Preserved, inst.InstallmentNumber)
+	}
+
+	var unpaid []int
+	for i, entry := range entries {
+		if !paid[entry.InstallmentNumber] {
+			unpaid = append(unpaid, i)
+		}
+	}
+
+	// O que as parcelas pagas deixaram de cobrir (ou cobriram a mais) vai para as não pagas
+	if delta != 0 {
+		if len(unpaid) == 0 {
+			return nil, fmt.Errorf("%w: paid installments do not match the new amount and no unpaid installments remain", entity.ErrConflict)
+		}
+
+		amounts := make([]int64, len(unpaid))
+		for i, idx := range unpaid {
+			amounts[i] = entries[idx].AmountCents
+		}
+		distributeRemainder(amounts, delta, transaction.RemainderPolicy)
+		for i, idx := range unpaid {
+			if amounts[i] <= 0 {
+				return nil, fmt.Errorf("%w: paid installments exceed the new transaction amount", entity.ErrConflict)
+			}
+			entries[idx].AmountCents = amounts[i]
+		}
+
+		changes.Warnings = append(changes.Warnings, fmt.Sprintf("a difference of %d cents from paid installments was redistributed across unpaid installments", delta))
+	}
+
+	for _, idx := range unpaid {
+		entry := entries[idx]
+		installment := &entity.Installment{
+			TransactionID:     transaction.ID,
+			InstallmentNumber: entry.InstallmentNumber,
+			AmountCents:       entry.AmountCents,
+			DueDate:           entry.DueDate,
+			Status:            entity.InstallmentStatusPending,
+		}
+
+		inst, ok := existingByNumber[entry.InstallmentNumber]
+		if !ok {
+			if err := u.repo.CreateInstallment(ctx, installment); err != nil {
+				return nil, fmt.Errorf("failed to create installment %d: %w", entry.InstallmentNumber, err)
+			}
+			changes.Created = append(changes.Created, entry.InstallmentNumber)
+			continue
+		}
+
+		if inst.AmountCents == entry.AmountCents && inst.DueDate.Equal(entry.DueDate) && inst.Status != entity.InstallmentStatusCancelled {
+			continue
+		}
+
+		if err := u.repo.UpdateInstallment(ctx, installment); err != nil {
+			return nil, err
+		}
+		changes.Updated = append(changes.Updated, entry.InstallmentNumber)
+	}
+
+	for _, inst := range existing {
+		if _, ok := planned[inst.InstallmentNumber]; ok {
+			continue
+		}
+		if err := u.repo.DeleteInstallment(ctx, transaction.ID, inst.InstallmentNumber); err != nil {
+			return nil, err
+		}
+		changes.Removed = append(changes.Removed, inst.InstallmentNumber)
+	}
+
+	return changes, nil
 }
 
 func (u *TransactionUsecase) Delete(ctx context.Context, id int64) error {
@@ -121,7 +655,10 @@ func (u *TransactionUsecase) Delete(ctx context.Context, id int64) error {
 		return fmt.Errorf("invalid transaction id")
 	}
 
-	return u.repo.Delete(ctx, id)
+	// As parcelas são removidas em cascata na mesma transação de banco
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		return u.repo.Delete(ctx, id)
+	})
 }
 
 func (u *TransactionUsecase) PayInstallment(ctx context.Context, transactionID int64, installmentNumber int) error {
@@ -129,10 +666,147 @@ func (u *TransactionUsecase) PayInstallment(ctx context.Context, transactionID i
 		return fmt.Errorf("invalid transaction id")
 	}
 
-	if installmentNumber <= 0 {
+	// A parcela 0 é a entrada
+	if installmentNumber < 0 {
 		return fmt.Errorf("invalid installment number")
 	}
 
-	return u.repo.PayInstallment(ctx, transactionID, installmentNumber)
+	return u.uow.Do(ctx, func(ctx context.Context) error {
+		installment, err := u.repo.GetInstallment(ctx, transactionID, installmentNumber)
+		if err != nil {
+			return err
+		}
+
+		if installment.Status == entity.InstallmentStatusCancelled {
+			return fmt.Errorf("%w: installment %d is cancelled", entity.ErrConflict, installmentNumber)
+		}
+
+		if installment.Status == entity.InstallmentStatusPaid {
+			return nil
+		}
+
+		// Quita o saldo em aberto, considerando pagamentos parciais já registrados
+		if _, err := u.settleInstallment(ctx, installment, time.Now()); err != nil {
+			return err
+		}
+
+		return u.repo.RefreshInstallmentStatus(ctx, transactionID)
+	})
+}
+
+// Pay quita uma transação simples. Sem data, usa o momento atual; sem valor, considera
+// pago o valor previsto. O valor informado é o total pago, incluindo pagamentos parciais
+// anteriores; a diferença é registrada como um novo pagamento.
+func (u *TransactionUsecase) Pay(ctx context.Context, id int64, paidAt *time.Time, paidAmountCents *int64) (*entity.Transaction, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid transaction id")
+	}
+
+	if paidAmountCents != nil && *paidAmountCents < 0 {
+		return nil, fmt.Errorf("paid amount cannot be negative")
+	}
+
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		transaction, err := u.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if transaction.IsInstallment {
+			return fmt.Errorf("%w: installment transactions are paid per installment", entity.ErrConflict)
+		}
+
+		if transaction.Type == entity.TransactionTypeTransfer {
+			return fmt.Errorf("%w: transfers have no payments", entity.ErrConflict)
+		}
+
+		switch transaction.Status {
+		case entity.TransactionStatusPaid, entity.TransactionStatusCancelled:
+			return fmt.Errorf("%w: transaction is %s", entity.ErrConflict, transaction.Status)
+		}
+
+		date := time.Now()
+		if paidAt != nil {
+			date = *paidAt
+		}
+
+		amount := transaction.AmountCents
+		if paidAmountCents != nil {
+			amount = *paidAmountCents
+		}
+
+		_, err = u.settleTransaction(ctx, id, date, amount)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetByID(ctx, id)
 }
 
+// Unpay desfaz o pagamento de uma transação simples, removendo todos os seus pagamentos
+func (u *TransactionUsecase) Unpay(ctx context.Context, id int64) (*entity.Transaction, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid transaction id")
+	}
+
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		transaction, err := u.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if transaction.IsInstallment {
+			return fmt.Errorf("%w: installment transactions are paid per installment", entity.ErrConflict)
+		}
+
+		paid, _, err := u.paymentRepo.SumByTarget(ctx, id, nil)
+		if err != nil {
+			return err
+		}
+
+		if transaction.Status != entity.TransactionStatusPaid && paid == 0 {
+			return fmt.Errorf("%w: transaction is not paid", entity.ErrConflict)
+		}
+
+		if err := u.paymentRepo.DeleteByTarget(ctx, id, nil); err != nil {
+			return err
+		}
+
+		return u.repo.Unpay(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetByID(ctx, id)
+}
+
+type OverdueSweepResult struct {
+	Transactions int64 `json:"transactions"`
+	Installments int64 `json:"installments"`
+}
+
+// MarkOverdue marca como vencidas as transações e parcelas pendentes cujo vencimento já passou
+func (u *TransactionUsecase) MarkOverdue(ctx context.Context, now time.Time) (*OverdueSweepResult, error) {
+	var transactions, installments int64
+	err := u.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		transactions, installments, err = u.repo.MarkOverdue(ctx, truncateDay(now))
+		if err != nil || installments == 0 {
+			return err
+		}
+
+		// Compras com parcelas recém-vencidas passam a ficar vencidas
+		return u.repo.RefreshInstallmentStatus(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OverdueSweepResult{
+		Transactions: transactions,
+		Installments: installments,
+	}, nil
+}
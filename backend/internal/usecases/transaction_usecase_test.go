@@ -0,0 +1,145 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"manager/internal/entity"
+)
+
+func TestMergeTransactionUpdateInstallmentTerms(t *testing.T) {
+	interval := 15
+	current := func() *entity.Transaction {
+		return &entity.Transaction{
+			IsInstallment:           true,
+			DueDate:                 time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			InstallmentSchedule:     entity.InstallmentScheduleEveryNDays,
+			InstallmentIntervalDays: &interval,
+			InterestRate:            1.5,
+			AmortizationMethod:      entity.AmortizationMethodPrice,
+			DownPaymentCents:        10000,
+			RemainderPolicy:         entity.RemainderPolicyLast,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		update entity.Transaction
+		fields entity.FieldSet
+		check  func(t *testing.T, got *entity.Transaction)
+	}{
+		{
+			name:   "omitted terms keep current values",
+			update: entity.Transaction{IsInstallment: true},
+			fields: entity.FieldSet{"is_installment": true},
+			check: func(t *testing.T, got *entity.Transaction) {
+				if got.InterestRate != 1.5 || got.DownPaymentCents != 10000 || got.InstallmentIntervalDays == nil || *got.InstallmentIntervalDays != 15 {
+					t.Errorf("terms not kept: %+v", got)
+				}
+			},
+		},
+		{
+			name:   "interest rate alone is applied",
+			update: entity.Transaction{IsInstallment: true, InterestRate: 2},
+			fields: entity.FieldSet{"is_installment": true, "interest_rate": true},
+			check: func(t *testing.T, got *entity.Transaction) {
+				if got.InterestRate != 2 || got.AmortizationMethod != entity.AmortizationMethodPrice || got.DownPaymentCents != 10000 {
+					t.Errorf("unexpected terms: %+v", got)
+				}
+			},
+		},
+		{
+			name:   "interest rate can be cleared",
+			update: entity.Transaction{IsInstallment: true, InterestRate: 0},
+			fields: entity.FieldSet{"is_installment": true, "interest_rate": true},
+			check: func(t *testing.T, got *entity.Transaction) {
+				if got.InterestRate != 0 {
+					t.Errorf("interest rate = %v, want 0", got.InterestRate)
+				}
+			},
+		},
+		{
+			name:   "down payment and interval alone are applied",
+			update: entity.Transaction{IsInstallment: true, DownPaymentCents: 0, InstallmentIntervalDays: nil},
+			fields: entity.FieldSet{"is_installment": true, "down_payment_cents": true, "installment_interval_days": true},
+			check: func(t *testing.T, got *entity.Transaction) {
+				if got.DownPaymentCents != 0 || got.InstallmentIntervalDays != nil || got.InterestRate != 1.5 {
+					t.Errorf("unexpected terms: %+v", got)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := tt.update
+			mergeTransactionUpdate(current(), &update, tt.fields)
+			tt.check(t, &update)
+		})
+	}
+}
+
+func TestMergeTransactionUpdateAccounts(t *testing.T) {
+	account, destination := int64(1), int64(2)
+	current := &entity.Transaction{AccountID: &account, DestinationAccountID: &destination}
+
+	tests := []struct {
+		name            string
+		fields          entity.FieldSet
+		wantAccount     *int64
+		wantDestination *int64
+	}{
+		{name: "omitted accounts are kept", fields: entity.FieldSet{"title": true}, wantAccount: &account, wantDestination: &destination},
+		{name: "explicit null clears the account", fields: entity.FieldSet{"account_id": true}, wantAccount: nil, wantDestination: &destination},
+		{name: "explicit null clears both", fields: entity.FieldSet{"account_id": true, "destination_account_id": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := &entity.Transaction{}
+			mergeTransactionUpdate(current, update, tt.fields)
+			if !sameID(update.AccountID, tt.wantAccount) || !sameID(update.DestinationAccountID, tt.wantDestination) {
+				t.Errorf("accounts = %v, %v; want %v, %v", update.AccountID, update.DestinationAccountID, tt.wantAccount, tt.wantDestination)
+			}
+		})
+	}
+}
+
+func sameID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func TestRecurringUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   bool
+		fields    entity.FieldSet
+		recurring bool
+		wantErr   bool
+	}{
+		{name: "omitted flag keeps the series", current: true, fields: entity.FieldSet{"title": true}},
+		{name: "unchanged flag", current: true, fields: entity.FieldSet{"is_recurring": true}, recurring: true},
+		{name: "enabling is rejected", current: false, fields: entity.FieldSet{"is_recurring": true}, recurring: true, wantErr: true},
+		{name: "disabling is rejected", current: true, fields: entity.FieldSet{"is_recurring": true}, recurring: false, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := &entity.Transaction{IsRecurring: tt.current}
+			update := &entity.Transaction{IsRecurring: tt.recurring}
+			mergeTransactionUpdate(current, update, tt.fields)
+
+			err := checkRecurringUpdate(current, update)
+			if tt.wantErr && !errors.Is(err, entity.ErrInvalidFilter) {
+				t.Errorf("error = %v, want %v", err, entity.ErrInvalidFilter)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
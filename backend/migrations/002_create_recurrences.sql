@@ -0,0 +1,27 @@
+-- Séries de transações recorrentes
+CREATE TABLE IF NOT EXISTS recurrences (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    title TEXT NOT NULL,
+    description TEXT,
+    amount_cents BIGINT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('income', 'expense')),
+    category_id BIGINT REFERENCES categories(id) ON DELETE SET NULL,
+    frequency TEXT NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly', 'yearly')),
+    interval_count INT NOT NULL DEFAULT 1 CHECK (interval_count > 0),
+    day_of_month INT CHECK (day_of_month BETWEEN 1 AND 31),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    max_occurrences INT CHECK (max_occurrences > 0),
+    occurrences_count INT NOT NULL DEFAULT 0,
+    next_due_date DATE NOT NULL,
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paused', 'finished')),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Vínculo das transações geradas com a série
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS recurrence_id BIGINT REFERENCES recurrences(id) ON DELETE SET NULL;
+
+-- Garante que cada ocorrência seja gerada uma única vez
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_recurrence_due_date ON transactions(recurrence_id, due_date);
+CREATE INDEX IF NOT EXISTS idx_recurrences_next_due_date ON recurrences(next_due_date) WHERE status = 'active';
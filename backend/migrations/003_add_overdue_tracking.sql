@@ -0,0 +1,6 @@
+-- Momento em que a transação/parcela passou a ficar vencida
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS overdue_at TIMESTAMPTZ;
+ALTER TABLE transaction_installments ADD COLUMN IF NOT EXISTS overdue_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_status ON transactions(status);
+CREATE INDEX IF NOT EXISTS idx_installments_status ON transaction_installments(status);
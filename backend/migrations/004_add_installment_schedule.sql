@@ -0,0 +1,10 @@
+-- Modo de agendamento das parcelas escolhido na criação da compra parcelada
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS installment_schedule TEXT
+    CHECK (installment_schedule IN ('monthly', 'every_n_days', 'weekly', 'custom'));
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS installment_interval_days INT
+    CHECK (installment_interval_days > 0);
+
+-- Compras parceladas existentes foram geradas com intervalos fixos de 30 dias
+UPDATE transactions
+SET installment_schedule = 'every_n_days', installment_interval_days = 30
+WHERE is_installment = true AND installment_schedule IS NULL;
@@ -0,0 +1,14 @@
+-- Juros, entrada e distribuição do arredondamento das compras parceladas
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS installment_interest_rate NUMERIC(9, 6) NOT NULL DEFAULT 0
+    CHECK (installment_interest_rate >= 0);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS amortization_method TEXT
+    CHECK (amortization_method IN ('price', 'sac'));
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS down_payment_cents BIGINT NOT NULL DEFAULT 0
+    CHECK (down_payment_cents >= 0);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS remainder_policy TEXT
+    CHECK (remainder_policy IN ('first', 'last', 'spread'));
+
+-- Compras parceladas existentes tinham o resto sempre na primeira parcela
+UPDATE transactions
+SET remainder_policy = 'first'
+WHERE is_installment = true AND remainder_policy IS NULL;
@@ -0,0 +1,21 @@
+-- Status derivado das parcelas: compra parcelada com parte das parcelas pagas
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_status_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_status_check
+    CHECK (status IN ('pending', 'paid', 'partially_paid', 'overdue', 'cancelled'));
+
+-- Recalcular o status das compras parceladas existentes
+UPDATE transactions t
+SET status = s.status, updated_at = NOW()
+FROM (
+    SELECT transaction_id,
+        CASE
+            WHEN COUNT(*) FILTER (WHERE status <> 'cancelled') = 0 THEN 'cancelled'
+            WHEN COUNT(*) FILTER (WHERE status = 'overdue') > 0 THEN 'overdue'
+            WHEN COUNT(*) FILTER (WHERE status = 'paid') = COUNT(*) FILTER (WHERE status <> 'cancelled') THEN 'paid'
+            WHEN COUNT(*) FILTER (WHERE status = 'paid') > 0 THEN 'partially_paid'
+            ELSE 'pending'
+        END AS status
+    FROM transaction_installments
+    GROUP BY transaction_id
+) s
+WHERE t.id = s.transaction_id AND t.is_installment = true AND t.status <> 'cancelled' AND t.status <> s.status;
@@ -0,0 +1,5 @@
+-- Pagamento de transações simples: data e valor efetivamente pago
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS paid_at TIMESTAMPTZ;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS paid_amount_cents BIGINT CHECK (paid_amount_cents >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_paid_at ON transactions(paid_at);
@@ -0,0 +1,26 @@
+-- Pagamentos: uma transação ou parcela pode ser quitada em vários pagamentos
+CREATE TABLE IF NOT EXISTS payments (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    transaction_id BIGINT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    installment_id BIGINT REFERENCES transaction_installments(id) ON DELETE CASCADE,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    paid_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    notes TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_transaction ON payments(transaction_id);
+CREATE INDEX IF NOT EXISTS idx_payments_installment ON payments(installment_id);
+
+-- Registrar como pagamento as parcelas e transações simples já pagas
+INSERT INTO payments (transaction_id, installment_id, amount_cents, paid_at)
+SELECT ti.transaction_id, ti.id, ti.amount_cents, COALESCE(ti.paid_at, ti.updated_at)
+FROM transaction_installments ti
+WHERE ti.status = 'paid' AND ti.amount_cents > 0
+AND NOT EXISTS (SELECT 1 FROM payments p WHERE p.installment_id = ti.id);
+
+INSERT INTO payments (transaction_id, amount_cents, paid_at)
+SELECT t.id, COALESCE(t.paid_amount_cents, t.amount_cents), COALESCE(t.paid_at, t.updated_at)
+FROM transactions t
+WHERE t.status = 'paid' AND t.is_installment = false AND COALESCE(t.paid_amount_cents, t.amount_cents) > 0
+AND NOT EXISTS (SELECT 1 FROM payments p WHERE p.transaction_id = t.id);
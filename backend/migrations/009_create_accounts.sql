@@ -0,0 +1,16 @@
+-- Contas/carteiras: conta corrente, poupança, dinheiro, cartão de crédito...
+CREATE TABLE IF NOT EXISTS accounts (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL DEFAULT 'checking' CHECK (type IN ('checking', 'savings', 'cash', 'credit_card', 'investment', 'other')),
+    opening_balance_cents BIGINT NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'BRL',
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Conta de cada transação (opcional)
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS account_id BIGINT REFERENCES accounts(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_account ON transactions(account_id);
@@ -0,0 +1,15 @@
+-- Transferências entre contas: account_id é a conta de origem e destination_account_id a de destino
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_type_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_type_check
+    CHECK (type IN ('income', 'expense', 'transfer'));
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS destination_account_id BIGINT REFERENCES accounts(id) ON DELETE RESTRICT;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_transfer_accounts_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transfer_accounts_check
+    CHECK (
+        (type = 'transfer' AND account_id IS NOT NULL AND destination_account_id IS NOT NULL AND account_id <> destination_account_id)
+        OR (type <> 'transfer' AND destination_account_id IS NULL)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_transactions_destination_account ON transactions(destination_account_id);
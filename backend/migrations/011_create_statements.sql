@@ -0,0 +1,23 @@
+-- Cartão de crédito: dia de fechamento e de vencimento da fatura
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS closing_day INT CHECK (closing_day BETWEEN 1 AND 31);
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS due_day INT CHECK (due_day BETWEEN 1 AND 31);
+
+-- Faturas: uma por cartão e data de fechamento
+CREATE TABLE IF NOT EXISTS statements (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    account_id BIGINT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    closing_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    paid_at TIMESTAMPTZ,
+    payment_transaction_id BIGINT REFERENCES transactions(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(account_id, closing_date)
+);
+
+-- Fatura de cada compra simples ou parcela
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS statement_id BIGINT REFERENCES statements(id) ON DELETE SET NULL;
+ALTER TABLE transaction_installments ADD COLUMN IF NOT EXISTS statement_id BIGINT REFERENCES statements(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_statement ON transactions(statement_id);
+CREATE INDEX IF NOT EXISTS idx_installments_statement ON transaction_installments(statement_id);
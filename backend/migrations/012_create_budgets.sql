@@ -0,0 +1,14 @@
+-- Orçamentos mensais por categoria
+CREATE TABLE IF NOT EXISTS budgets (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    category_id BIGINT NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    month DATE NOT NULL CHECK (EXTRACT(DAY FROM month) = 1),
+    limit_cents BIGINT NOT NULL CHECK (limit_cents > 0),
+    -- O valor não gasto no mês é somado ao orçamento do mês seguinte
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(category_id, month)
+);
+
+CREATE INDEX IF NOT EXISTS idx_budgets_month ON budgets(month);
@@ -0,0 +1,34 @@
+-- Regras de alerta avaliadas após cada escrita de transação e periodicamente
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('budget_threshold', 'low_balance', 'bill_due')),
+    -- budget_threshold: percentual do orçamento (ex: 80, 100); category_id opcional restringe a uma categoria
+    threshold_percent INT CHECK (threshold_percent > 0),
+    category_id BIGINT REFERENCES categories(id) ON DELETE CASCADE,
+    -- low_balance: saldo mínimo; account_id opcional (sem conta, usa o saldo total)
+    threshold_cents BIGINT,
+    account_id BIGINT REFERENCES accounts(id) ON DELETE CASCADE,
+    -- bill_due: antecedência em dias em relação ao vencimento
+    days_ahead INT CHECK (days_ahead >= 0),
+    -- Canais de entrega além da lista no aplicativo: email, webhook
+    channels TEXT[] NOT NULL DEFAULT '{}',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Notificações geradas pelas regras. dedupe_key impede que a mesma condição notifique mais de
+-- uma vez (ex: orçamento da categoria no mês, saldo baixo no dia, vencimento de uma parcela)
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    rule_id BIGINT REFERENCES alert_rules(id) ON DELETE SET NULL,
+    type TEXT NOT NULL,
+    title TEXT NOT NULL,
+    message TEXT NOT NULL,
+    dedupe_key TEXT NOT NULL UNIQUE,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(created_at DESC) WHERE read_at IS NULL;
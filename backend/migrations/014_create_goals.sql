@@ -0,0 +1,24 @@
+-- Metas de economia (reserva de emergência, viagem...)
+CREATE TABLE IF NOT EXISTS goals (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    target_cents BIGINT NOT NULL CHECK (target_cents > 0),
+    target_date DATE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- Aportes nas metas. Valores negativos são resgates. Um aporte pode estar vinculado à
+-- transação que o originou e é excluído junto com ela.
+CREATE TABLE IF NOT EXISTS goal_contributions (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    goal_id BIGINT NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    transaction_id BIGINT REFERENCES transactions(id) ON DELETE CASCADE,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents <> 0),
+    contributed_at DATE NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(goal_id, transaction_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_goal_contributions_goal ON goal_contributions(goal_id, contributed_at);
@@ -0,0 +1,8 @@
+-- Subcategorias: parent_id aponta para a categoria pai (ex: Alimentação > Mercado).
+-- Ao excluir a categoria pai, as subcategorias passam a ser categorias de primeiro nível.
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id BIGINT REFERENCES categories(id) ON DELETE SET NULL;
+
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_parent_not_self;
+ALTER TABLE categories ADD CONSTRAINT categories_parent_not_self CHECK (parent_id IS NULL OR parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent ON categories(parent_id);
@@ -0,0 +1,6 @@
+-- Valor combinado na quitação de uma transação simples (ex: pago com desconto). É mantido
+-- enquanto restarem pagamentos, para que a transação volte a ser paga ao atingi-lo.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS settled_amount_cents BIGINT CHECK (settled_amount_cents >= 0);
+
+UPDATE transactions SET settled_amount_cents = paid_amount_cents
+WHERE status = 'paid' AND is_installment = false AND paid_amount_cents IS NOT NULL AND settled_amount_cents IS NULL;
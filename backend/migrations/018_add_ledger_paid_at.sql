@@ -0,0 +1,42 @@
+-- Data de quitação dos lançamentos, para agrupar valores quitados pela data do pagamento.
+--   paid_at: quando a transação simples ou a parcela foi quitada (nulo se ainda em aberto).
+--            Pagamentos parciais têm a própria data em payments.
+CREATE OR REPLACE VIEW ledger_entries AS
+SELECT
+    t.id AS transaction_id,
+    NULL::BIGINT AS installment_id,
+    NULL::INT AS installment_number,
+    t.type,
+    t.category_id,
+    t.account_id,
+    t.destination_account_id,
+    t.due_date AS date,
+    t.status,
+    COALESCE(t.paid_amount_cents, t.amount_cents) AS amount_cents,
+    CASE
+        WHEN t.type = 'transfer' OR t.status = 'paid' THEN COALESCE(t.paid_amount_cents, t.amount_cents)
+        ELSE COALESCE((SELECT SUM(p.amount_cents) FROM payments p WHERE p.transaction_id = t.id AND p.installment_id IS NULL), 0)
+    END AS paid_cents,
+    t.paid_at
+FROM transactions t
+WHERE t.is_installment = false AND t.status != 'cancelled'
+UNION ALL
+SELECT
+    t.id,
+    ti.id,
+    ti.installment_number,
+    t.type,
+    t.category_id,
+    t.account_id,
+    t.destination_account_id,
+    ti.due_date,
+    ti.status,
+    ti.amount_cents,
+    CASE
+        WHEN ti.status = 'paid' THEN ti.amount_cents
+        ELSE COALESCE((SELECT SUM(p.amount_cents) FROM payments p WHERE p.installment_id = ti.id), 0)
+    END,
+    ti.paid_at
+FROM transaction_installments ti
+INNER JOIN transactions t ON ti.transaction_id = t.id
+WHERE t.is_installment = true AND t.status != 'cancelled' AND ti.status != 'cancelled';
@@ -0,0 +1,3 @@
+-- Conta em que o dinheiro da meta é guardado (ex: poupança). Aportes vinculados a transações
+-- dessas metas devem ser transferências pagas para essa conta.
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS account_id BIGINT REFERENCES accounts(id) ON DELETE SET NULL;
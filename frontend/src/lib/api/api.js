@@ -21,7 +21,8 @@ async function request(endpoint, options = {}) {
 export const api = {
 	// Transactions
 	transactions: {
-		getAll: () => request('/transactions'),
+		// Retorna { data, total, next_cursor }
+		getAll: (params = {}) => request(`/transactions?${new URLSearchParams(params)}`),
 		getById: (id) => request(`/transactions/${id}`),
 		create: (data) => request('/transactions', { method: 'POST', body: JSON.stringify(data) }),
 		update: (id, data) => request(`/transactions/${id}`, { method: 'PUT', body: JSON.stringify(data) }),
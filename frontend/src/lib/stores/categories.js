@@ -1,28 +1,48 @@
-import { writable } from 'svelte/store';
+import { writable, derived } from 'svelte/store';
 import { api } from '../api/api.js';
 
+// Achatar a árvore de categorias, com a profundidade e o caminho de cada uma (ex: "Alimentação › Mercado")
+export function flattenCategories(tree, depth = 0, path = []) {
+	return tree.flatMap((category) => {
+		const fullPath = [...path, category.name];
+		return [
+			{ ...category, depth, path: fullPath.join(' › ') },
+			...flattenCategories(category.children || [], depth + 1, fullPath)
+		];
+	});
+}
+
+// IDs da categoria e de todas as suas subcategorias
+export function subtreeIds(category) {
+	return [category.id, ...(category.children || []).flatMap(subtreeIds)];
+}
+
 function createCategoriesStore() {
-	const { subscribe, set, update } = writable([]);
+	// A API retorna as categorias em árvore: as de primeiro nível, com as subcategorias em children
+	const { subscribe, set } = writable([]);
 	let loading = false;
 
+	async function load() {
+		if (loading) return;
+		loading = true;
+		try {
+			const categories = await api.categories.getAll();
+			set(categories);
+		} catch (error) {
+			console.error('Failed to load categories:', error);
+		} finally {
+			loading = false;
+		}
+	}
+
 	return {
 		subscribe,
-		load: async () => {
-			if (loading) return;
-			loading = true;
-			try {
-				const categories = await api.categories.getAll();
-				set(categories);
-			} catch (error) {
-				console.error('Failed to load categories:', error);
-			} finally {
-				loading = false;
-			}
-		},
+		load,
+		// Após cada alteração a árvore é recarregada, pois a categoria pode mudar de posição
 		add: async (category) => {
 			try {
 				const newCategory = await api.categories.create(category);
-				update((categories) => [...categories, newCategory]);
+				await load();
 				return newCategory;
 			} catch (error) {
 				console.error('Failed to create category:', error);
@@ -32,9 +52,7 @@ function createCategoriesStore() {
 		update: async (id, category) => {
 			try {
 				const updated = await api.categories.update(id, category);
-				update((categories) =>
-					categories.map((c) => (c.id === id ? updated : c))
-				);
+				await load();
 				return updated;
 			} catch (error) {
 				console.error('Failed to update category:', error);
@@ -44,7 +62,7 @@ function createCategoriesStore() {
 		delete: async (id) => {
 			try {
 				await api.categories.delete(id);
-				update((categories) => categories.filter((c) => c.id !== id));
+				await load();
 			} catch (error) {
 				console.error('Failed to delete category:', error);
 				throw error;
@@ -55,3 +73,5 @@ function createCategoriesStore() {
 
 export const categories = createCategoriesStore();
 
+// Todas as categorias, de qualquer nível, na ordem da árvore (para selects)
+export const categoryList = derived(categories, ($categories) => flattenCategories($categories));
@@ -11,7 +11,16 @@ function createTransactionsStore() {
 			if (loading) return;
 			loading = true;
 			try {
-				const transactions = await api.transactions.getAll();
+				// Percorrer todas as páginas da listagem
+				const transactions = [];
+				let cursor = null;
+				do {
+					const params = { limit: 500 };
+					if (cursor) params.cursor = cursor;
+					const page = await api.transactions.getAll(params);
+					transactions.push(...page.data);
+					cursor = page.next_cursor;
+				} while (cursor);
 				set(transactions);
 			} catch (error) {
 				console.error('Failed to load transactions:', error);